@@ -3,8 +3,12 @@ package model
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path"
+	"strings"
 
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
@@ -14,6 +18,25 @@ type Account struct {
 	URL        string `json:"url"`
 	PrivateKey string `json:"privateKey"`
 	PublicKey  string `json:"publicKey"`
+
+	// Chain the account is derived for, empty for accounts created before chains were introduced
+	Chain       string `json:"chain,omitempty"`
+	AddressType string `json:"addressType,omitempty"`
+	Network     string `json:"network,omitempty"`
+	Prefix      string `json:"prefix,omitempty"`
+
+	// Profile is the name of the chain profile the account was created for
+	Profile string `json:"profile,omitempty"`
+
+	// EntityID binds the account to the Vault identity entity allowed to sign with it
+	EntityID string `json:"entityID,omitempty"`
+
+	// RequireKnownCalldata refuses calldata which does not decode against a registered contract ABI
+	RequireKnownCalldata bool `json:"requireKnownCalldata,omitempty"`
+
+	// AllowedEntryPoints and AllowedSenders restrict the ERC-4337 user operations the account signs
+	AllowedEntryPoints []string `json:"allowedEntryPoints,omitempty"`
+	AllowedSenders     []string `json:"allowedSenders,omitempty"`
 }
 
 // ReadAccount returns the account JSON
@@ -37,3 +60,79 @@ func ReadAccount(ctx context.Context, req *logical.Request) (*Account, error) {
 
 	return account, nil
 }
+
+// SelfIndexPath is the storage path of the index of the next self-provisioned account
+const SelfIndexPath = "self-index"
+
+// SelfAccountPrefix prefixes the names of self-provisioned accounts, which
+// accounts created or updated under accounts/ cannot take
+const SelfAccountPrefix = "self-"
+
+// SelfAccountName returns the name of the account provisioned for the entity
+func SelfAccountName(entityID string) string {
+	return SelfAccountPrefix + entityID
+}
+
+// IsSelfAccountName reports whether the name is reserved for self-provisioned accounts
+func IsSelfAccountName(name string) bool {
+	return strings.HasPrefix(name, SelfAccountPrefix)
+}
+
+// SelfAccountBase is the first hardened account level of self-provisioned
+// accounts, which are derived at m/44'/60'/<SelfAccountBase + index>'/0/0 so
+// they never share a key with the accounts created under accounts/
+const SelfAccountBase = 0x40000000
+
+// SelfDerivationPath returns the derivation path of the self-provisioned account of the index
+func SelfDerivationPath(index uint32) (accounts.DerivationPath, error) {
+	if index >= hdkeychain.HardenedKeyStart-SelfAccountBase {
+		return nil, fmt.Errorf("self-provisioned account index %d is out of range", index)
+	}
+	return accounts.DerivationPath{
+		hdkeychain.HardenedKeyStart + 44,
+		hdkeychain.HardenedKeyStart + 60,
+		hdkeychain.HardenedKeyStart + SelfAccountBase + index,
+		0,
+		0,
+	}, nil
+}
+
+// IsSelfDerivationPath reports whether the path is in the branch reserved for self-provisioned accounts
+func IsSelfDerivationPath(path accounts.DerivationPath) bool {
+	return len(path) >= 3 &&
+		path[0] == hdkeychain.HardenedKeyStart+44 &&
+		path[1] == hdkeychain.HardenedKeyStart+60 &&
+		path[2] >= hdkeychain.HardenedKeyStart+SelfAccountBase
+}
+
+// AllowsEntity reports whether the entity may sign with the account
+func (a *Account) AllowsEntity(entityID string) bool {
+	return a.EntityID == "" || a.EntityID == entityID
+}
+
+// ChainName returns the chain of the account, defaulting to ethereum
+func (a *Account) ChainName() string {
+	if a.Chain == "" {
+		return ChainEthereum
+	}
+	return a.Chain
+}
+
+// AllowsEntryPoint reports whether the account signs user operations for the EntryPoint
+func (a *Account) AllowsEntryPoint(entryPoint string) bool {
+	return len(a.AllowedEntryPoints) == 0 || containsFold(a.AllowedEntryPoints, entryPoint)
+}
+
+// AllowsSender reports whether the account signs user operations of the smart account
+func (a *Account) AllowsSender(sender string) bool {
+	return len(a.AllowedSenders) == 0 || containsFold(a.AllowedSenders, sender)
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package model
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultMnemonicLanguage is the wordlist of generated mnemonics
+const DefaultMnemonicLanguage = "english"
+
+// mnemonicLanguages are the BIP-39 wordlists, in the order ambiguous mnemonics are resolved
+var mnemonicLanguages = []struct {
+	name  string
+	words []string
+}{
+	{"english", wordlists.English},
+	{"japanese", wordlists.Japanese},
+	{"korean", wordlists.Korean},
+	{"spanish", wordlists.Spanish},
+	{"chinese_simplified", wordlists.ChineseSimplified},
+	{"chinese_traditional", wordlists.ChineseTraditional},
+	{"french", wordlists.French},
+	{"italian", wordlists.Italian},
+	{"czech", czechWordlist},
+	{"portuguese", portugueseWordlist},
+}
+
+// mnemonicWordIndex maps the NFKD normalized words of each language to their index
+var mnemonicWordIndex = func() map[string]map[string]int {
+	index := map[string]map[string]int{}
+	for _, language := range mnemonicLanguages {
+		words := make(map[string]int, len(language.words))
+		for i, word := range language.words {
+			words[norm.NFKD.String(word)] = i
+		}
+		index[language.name] = words
+	}
+	return index
+}()
+
+// MnemonicLanguages returns the names of the supported BIP-39 wordlists
+func MnemonicLanguages() []string {
+	names := make([]string, 0, len(mnemonicLanguages))
+	for _, language := range mnemonicLanguages {
+		names = append(names, language.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewMnemonic returns the BIP-39 mnemonic of the entropy in the language
+func NewMnemonic(entropy []byte, language string) (string, error) {
+	var words []string
+	for _, candidate := range mnemonicLanguages {
+		if candidate.name == language {
+			words = candidate.words
+		}
+	}
+	if words == nil {
+		return "", fmt.Errorf("unsupported language %s, must be one of %s", language, strings.Join(MnemonicLanguages(), ", "))
+	}
+
+	bits := len(entropy) * 8
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", fmt.Errorf("invalid entropy of %d bits, must be a multiple of 32 between 128 and 256", bits)
+	}
+
+	checksum := sha256.Sum256(entropy)
+	checksumBits := uint(bits / 32)
+	value := new(big.Int).SetBytes(entropy)
+	value.Lsh(value, checksumBits)
+	value.Or(value, big.NewInt(int64(checksum[0]>>(8-checksumBits))))
+
+	sentence := make([]string, (bits+int(checksumBits))/11)
+	for i := len(sentence) - 1; i >= 0; i-- {
+		sentence[i] = words[new(big.Int).And(value, big.NewInt(2047)).Int64()]
+		value.Rsh(value, 11)
+	}
+
+	// Japanese mnemonics are separated by ideographic spaces
+	separator := " "
+	if language == "japanese" {
+		separator = "　"
+	}
+	return strings.Join(sentence, separator), nil
+}
+
+// MnemonicLanguage detects the language of the mnemonic and verifies its checksum
+func MnemonicLanguage(mnemonic string) (string, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return "", errors.New("mnemonic is invalid")
+	}
+
+	// some words are shared by several wordlists, such as the Chinese ones,
+	// only the checksum tells them apart
+	matched := false
+	for _, language := range mnemonicLanguages {
+		indices, ok := mnemonicIndices(words, mnemonicWordIndex[language.name])
+		if !ok {
+			continue
+		}
+		matched = true
+		if validMnemonicChecksum(indices) {
+			return language.name, nil
+		}
+	}
+
+	if matched {
+		return "", errors.New("mnemonic checksum is invalid")
+	}
+	return "", errors.New("mnemonic is invalid")
+}
+
+// NormalizeMnemonic returns the NFKD normalized mnemonic sentence and
+// passphrase BIP-39 derives the seed from
+func NormalizeMnemonic(mnemonic string, passphrase string) (string, string) {
+	return strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " "), norm.NFKD.String(passphrase)
+}
+
+func mnemonicIndices(words []string, wordIndex map[string]int) ([]int, bool) {
+	indices := make([]int, len(words))
+	for i, word := range words {
+		index, ok := wordIndex[word]
+		if !ok {
+			return nil, false
+		}
+		indices[i] = index
+	}
+	return indices, true
+}
+
+func validMnemonicChecksum(indices []int) bool {
+	value := new(big.Int)
+	for _, index := range indices {
+		value.Lsh(value, 11)
+		value.Or(value, big.NewInt(int64(index)))
+	}
+
+	checksumBits := uint(len(indices) * 11 / 33)
+	checksum := new(big.Int).And(value, big.NewInt(1<<checksumBits-1)).Int64()
+
+	entropy := make([]byte, len(indices)*11*32/33/8)
+	entropyBytes := value.Rsh(value, checksumBits).Bytes()
+	copy(entropy[len(entropy)-len(entropyBytes):], entropyBytes)
+
+	hash := sha256.Sum256(entropy)
+	return int64(hash[0]>>(8-checksumBits)) == checksum
+}
+
+// mnemonicSeed is the BIP-39 seed of the normalized mnemonic and passphrase
+func mnemonicSeed(mnemonic string, passphrase string) []byte {
+	mnemonic, passphrase = NormalizeMnemonic(mnemonic, passphrase)
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase), 2048, 64, sha512.New)
+}
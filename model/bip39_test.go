@@ -0,0 +1,94 @@
+package model
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// the mnemonic of 128 zero bits repeats the first word of the wordlist and
+// ends with its fourth, the checksum being 0011
+func TestNewMnemonicLanguages(t *testing.T) {
+	vectors := []struct {
+		language string
+		first    string
+		last     string
+	}{
+		{"english", "abandon", "about"},
+		{"japanese", "あいこくしん", "あおぞら"},
+		{"korean", "가격", "가능"},
+		{"spanish", "ábaco", "abierto"},
+		{"chinese_simplified", "的", "在"},
+		{"chinese_traditional", "的", "在"},
+		{"french", "abaisser", "abeille"},
+		{"italian", "abaco", "abete"},
+		{"czech", "abdikace", "agrese"},
+		{"portuguese", "abacate", "abater"},
+	}
+	if len(vectors) != len(MnemonicLanguages()) {
+		t.Fatalf("got %d languages, want %d", len(MnemonicLanguages()), len(vectors))
+	}
+
+	for _, vector := range vectors {
+		mnemonic, err := NewMnemonic(make([]byte, 16), vector.language)
+		if err != nil {
+			t.Fatalf("%s: %v", vector.language, err)
+		}
+		// wordlists may hold composed or decomposed characters, BIP-39 compares them NFKD normalized
+		normalized, _ := NormalizeMnemonic(mnemonic, "")
+		first, _ := NormalizeMnemonic(vector.first, "")
+		last, _ := NormalizeMnemonic(vector.last, "")
+		words := strings.Fields(normalized)
+		if len(words) != 12 || words[0] != first || words[10] != first || words[11] != last {
+			t.Errorf("%s: got mnemonic %s", vector.language, mnemonic)
+		}
+
+		// mnemonics of the Chinese wordlists sharing these words are resolved in list order
+		language, err := MnemonicLanguage(mnemonic)
+		if err != nil {
+			t.Fatalf("%s: %v", vector.language, err)
+		}
+		if language != vector.language && vector.language != "chinese_traditional" {
+			t.Errorf("%s: mnemonic detected as %s", vector.language, language)
+		}
+	}
+}
+
+// every wordlist holds 2048 distinct words, so each 11-bit value has one word
+func TestMnemonicWordlists(t *testing.T) {
+	for _, language := range mnemonicLanguages {
+		if len(language.words) != 2048 || len(mnemonicWordIndex[language.name]) != 2048 {
+			t.Errorf("%s: got %d words, %d distinct", language.name, len(language.words), len(mnemonicWordIndex[language.name]))
+		}
+	}
+}
+
+// vectors of the Trezor reference implementation, python-mnemonic, for English and Japanese
+func TestNewSeedFromMnemonic(t *testing.T) {
+	vectors := []struct {
+		mnemonic   string
+		passphrase string
+		seed       string
+	}{
+		{
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"TREZOR",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+			"㍍ガバヴァぱばぐゞちぢ十人十色",
+			"a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55",
+		},
+	}
+
+	for _, vector := range vectors {
+		seed, err := NewSeedFromMnemonic(vector.mnemonic, vector.passphrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(seed) != vector.seed {
+			t.Errorf("got seed %x, want %s", seed, vector.seed)
+		}
+	}
+}
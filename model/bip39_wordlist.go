@@ -0,0 +1,427 @@
+package model
+
+import "strings"
+
+// czechWordlist holds the 2048 words of the Czech BIP-39 wordlist, indexed by their 11-bit value
+var czechWordlist = strings.Fields(`
+abdikace abeceda adresa agrese akce aktovka alej alkohol amputace ananas
+andulka anekdota anketa antika anulovat archa arogance asfalt asistent
+aspirace astma astronom atlas atletika atol autobus azyl babka bachor bacil
+baculka badatel bageta bagr bahno bakterie balada baletka balkon balonek
+balvan balza bambus bankomat barbar baret barman baroko barva baterka batoh
+bavlna bazalka bazilika bazuka bedna beran beseda bestie beton bezinka
+bezmoc beztak bicykl bidlo biftek bikiny bilance biograf biolog bitva bizon
+blahobyt blatouch blecha bledule blesk blikat blizna blokovat bloudit blud
+bobek bobr bodlina bodnout bohatost bojkot bojovat bokorys bolest borec
+borovice bota boubel bouchat bouda boule bourat boxer bradavka brambora
+branka bratr brepta briketa brko brloh bronz broskev brunetka brusinka brzda
+brzy bublina bubnovat buchta buditel budka budova bufet bujarost bukvice
+buldok bulva bunda bunkr burza butik buvol buzola bydlet bylina bytovka
+bzukot capart carevna cedr cedule cejch cejn cela celer celkem celnice
+cenina cennost cenovka centrum cenzor cestopis cetka chalupa chapadlo
+charita chata chechtat chemie chichot chirurg chlad chleba chlubit chmel
+chmura chobot chochol chodba cholera chomout chopit choroba chov chrapot
+chrlit chrt chrup chtivost chudina chutnat chvat chvilka chvost chyba
+chystat chytit cibule cigareta cihelna cihla cinkot cirkus cisterna citace
+citrus cizinec cizost clona cokoliv couvat ctitel ctnost cudnost cuketa cukr
+cupot cvaknout cval cvik cvrkot cyklista daleko dareba datel datum dcera
+debata dechovka decibel deficit deflace dekl dekret demokrat deprese derby
+deska detektiv dikobraz diktovat dioda diplom disk displej divadlo divoch
+dlaha dlouho dluhopis dnes dobro dobytek docent dochutit dodnes dohled
+dohoda dohra dojem dojnice doklad dokola doktor dokument dolar doleva dolina
+doma dominant domluvit domov donutit dopad dopis doplnit doposud doprovod
+dopustit dorazit dorost dort dosah doslov dostatek dosud dosyta dotaz dotek
+dotknout doufat doutnat dovozce dozadu doznat dozorce drahota drak dramatik
+dravec draze drdol drobnost drogerie drozd drsnost drtit drzost duben
+duchovno dudek duha duhovka dusit dusno dutost dvojice dvorec dynamit ekolog
+ekonomie elektron elipsa email emise emoce empatie epizoda epocha epopej
+epos esej esence eskorta eskymo etiketa euforie evoluce exekuce exkurze
+expedice exploze export extrakt facka fajfka fakulta fanatik fantazie
+farmacie favorit fazole federace fejeton fenka fialka figurant filozof filtr
+finance finta fixace fjord flanel flirt flotila fond fosfor fotbal fotka
+foton frakce freska fronta fukar funkce fyzika galeje garant genetika geolog
+gilotina glazura glejt golem golfista gotika graf gramofon granule grep gril
+grog groteska guma hadice hadr hala halenka hanba hanopis harfa harpuna
+havran hebkost hejkal hejno hejtman hektar helma hematom herec herna heslo
+hezky historik hladovka hlasivky hlava hledat hlen hlodavec hloh hloupost
+hltat hlubina hluchota hmat hmota hmyz hnis hnojivo hnout hoblina hoboj hoch
+hodiny hodlat hodnota hodovat hojnost hokej holinka holka holub homole
+honitba honorace horal horda horizont horko horlivec hormon hornina horoskop
+horstvo hospoda hostina hotovost houba houf houpat houska hovor hradba
+hranice hravost hrazda hrbolek hrdina hrdlo hrdost hrnek hrobka hromada hrot
+hrouda hrozen hrstka hrubost hryzat hubenost hubnout hudba hukot humr husita
+hustota hvozd hybnost hydrant hygiena hymna hysterik idylka ihned ikona
+iluze imunita infekce inflace inkaso inovace inspekce internet invalida
+investor inzerce ironie jablko jachta jahoda jakmile jakost jalovec jantar
+jarmark jaro jasan jasno jatka javor jazyk jedinec jedle jednatel jehlan
+jekot jelen jelito jemnost jenom jepice jeseter jevit jezdec jezero jinak
+jindy jinoch jiskra jistota jitrnice jizva jmenovat jogurt jurta kabaret
+kabel kabinet kachna kadet kadidlo kahan kajak kajuta kakao kaktus kalamita
+kalhoty kalibr kalnost kamera kamkoliv kamna kanibal kanoe kantor kapalina
+kapela kapitola kapka kaple kapota kapr kapusta kapybara karamel karotka
+karton kasa katalog katedra kauce kauza kavalec kazajka kazeta kazivost
+kdekoliv kdesi kedluben kemp keramika kino klacek kladivo klam klapot
+klasika klaun klec klenba klepat klesnout klid klima klisna klobouk klokan
+klopa kloub klubovna klusat kluzkost kmen kmitat kmotr kniha knot koalice
+koberec kobka kobliha kobyla kocour kohout kojenec kokos koktejl kolaps
+koleda kolize kolo komando kometa komik komnata komora kompas komunita konat
+koncept kondice konec konfese kongres konina konkurs kontakt konzerva
+kopanec kopie kopnout koprovka korbel korektor kormidlo koroptev korpus
+koruna koryto korzet kosatec kostka kotel kotleta kotoul koukat koupelna
+kousek kouzlo kovboj koza kozoroh krabice krach krajina kralovat krasopis
+kravata kredit krejcar kresba kreveta kriket kritik krize krkavec krmelec
+krmivo krocan krok kronika kropit kroupa krovka krtek kruhadlo krupice
+krutost krvinka krychle krypta krystal kryt kudlanka kufr kujnost kukla
+kulajda kulich kulka kulomet kultura kuna kupodivu kurt kurzor kutil kvalita
+kvasinka kvestor kynolog kyselina kytara kytice kytka kytovec kyvadlo
+labrador lachtan ladnost laik lakomec lamela lampa lanovka lasice laso
+lastura latinka lavina lebka leckdy leden lednice ledovka ledvina legenda
+legie legrace lehce lehkost lehnout lektvar lenochod lentilka lepenka
+lepidlo letadlo letec letmo letokruh levhart levitace levobok libra lichotka
+lidojed lidskost lihovina lijavec lilek limetka linie linka linoleum
+listopad litina litovat lobista lodivod logika logoped lokalita loket
+lomcovat lopata lopuch lord losos lotr loudal louh louka louskat lovec
+lstivost lucerna lucifer lump lusk lustrace lvice lyra lyrika lysina madam
+madlo magistr mahagon majetek majitel majorita makak makovice makrela malba
+malina malovat malvice maminka mandle manko marnost masakr maskot masopust
+matice matrika maturita mazanec mazivo mazlit mazurka mdloba mechanik
+meditace medovina melasa meloun mentolka metla metoda metr mezera migrace
+mihnout mihule mikina mikrofon milenec milimetr milost mimika mincovna
+minibar minomet minulost miska mistr mixovat mladost mlha mlhovina mlok
+mlsat mluvit mnich mnohem mobil mocnost modelka modlitba mohyla mokro
+molekula momentka monarcha monokl monstrum montovat monzun mosaz moskyt most
+motivace motorka motyka moucha moudrost mozaika mozek mozol mramor mravenec
+mrkev mrtvola mrzet mrzutost mstitel mudrc muflon mulat mumie munice muset
+mutace muzeum muzikant myslivec mzda nabourat nachytat nadace nadbytek
+nadhoz nadobro nadpis nahlas nahnat nahodile nahradit naivita najednou
+najisto najmout naklonit nakonec nakrmit nalevo namazat namluvit nanometr
+naoko naopak naostro napadat napevno naplnit napnout naposled naprosto
+narodit naruby narychlo nasadit nasekat naslepo nastat natolik navenek
+navrch navzdory nazvat nebe nechat necky nedaleko nedbat neduh negace nehet
+nehoda nejen nejprve neklid nelibost nemilost nemoc neochota neonka nepokoj
+nerost nerv nesmysl nesoulad netvor neuron nevina nezvykle nicota nijak
+nikam nikdy nikl nikterak nitro nocleh nohavice nominace nora norek nositel
+nosnost nouze noviny novota nozdra nuda nudle nuget nutit nutnost nutrie
+nymfa obal obarvit obava obdiv obec obehnat obejmout obezita obhajoba
+obilnice objasnit objekt obklopit oblast oblek obliba obloha obluda obnos
+obohatit obojek obout obrazec obrna obruba obrys obsah obsluha obstarat obuv
+obvaz obvinit obvod obvykle obyvatel obzor ocas ocel ocenit ochladit ochota
+ochrana ocitnout odboj odbyt odchod odcizit odebrat odeslat odevzdat odezva
+odhadce odhodit odjet odjinud odkaz odkoupit odliv odluka odmlka odolnost
+odpad odpis odplout odpor odpustit odpykat odrazka odsoudit odstup odsun
+odtok odtud odvaha odveta odvolat odvracet odznak ofina ofsajd ohlas ohnisko
+ohrada ohrozit ohryzek okap okenice oklika okno okouzlit okovy okrasa okres
+okrsek okruh okupant okurka okusit olejnina olizovat omak omeleta omezit
+omladina omlouvat omluva omyl onehdy opakovat opasek operace opice opilost
+opisovat opora opozice opravdu oproti orbital orchestr orgie orlice orloj
+ortel osada oschnout osika osivo oslava oslepit oslnit oslovit osnova osoba
+osolit ospalec osten ostraha ostuda ostych osvojit oteplit otisk otop otrhat
+otrlost otrok otruby otvor ovanout ovar oves ovlivnit ovoce oxid ozdoba
+pachatel pacient padouch pahorek pakt palanda palec palivo paluba pamflet
+pamlsek panenka panika panna panovat panstvo pantofle paprika parketa
+parodie parta paruka paryba paseka pasivita pastelka patent patrona pavouk
+pazneht pazourek pecka pedagog pejsek peklo peloton penalta pendrek penze
+periskop pero pestrost petarda petice petrolej pevnina pexeso pianista piha
+pijavice pikle piknik pilina pilnost pilulka pinzeta pipeta pisatel pistole
+pitevna pivnice pivovar placenta plakat plamen planeta plastika platit
+plavidlo plaz plech plemeno plenta ples pletivo plevel plivat plnit plno
+plocha plodina plomba plout pluk plyn pobavit pobyt pochod pocit poctivec
+podat podcenit podepsat podhled podivit podklad podmanit podnik podoba
+podpora podraz podstata podvod podzim poezie pohanka pohnutka pohovor
+pohroma pohyb pointa pojistka pojmout pokazit pokles pokoj pokrok pokuta
+pokyn poledne polibek polknout poloha polynom pomalu pominout pomlka pomoc
+pomsta pomyslet ponechat ponorka ponurost popadat popel popisek poplach
+poprosit popsat popud poradce porce porod porucha poryv posadit posed posila
+poskok poslanec posoudit pospolu postava posudek posyp potah potkan potlesk
+potomek potrava potupa potvora poukaz pouto pouzdro povaha povidla povlak
+povoz povrch povstat povyk povzdech pozdrav pozemek poznatek pozor pozvat
+pracovat prahory praktika prales praotec praporek prase pravda princip prkno
+probudit procento prodej profese prohra projekt prolomit promile pronikat
+propad prorok prosba proton proutek provaz prskavka prsten prudkost prut
+prvek prvohory psanec psovod pstruh ptactvo puberta puch pudl pukavec
+puklina pukrle pult pumpa punc pupen pusa pusinka pustina putovat putyka
+pyramida pysk pytel racek rachot radiace radnice radon raft ragby raketa
+rakovina rameno rampouch rande rarach rarita rasovna rastr ratolest razance
+razidlo reagovat reakce recept redaktor referent reflex rejnok reklama
+rekord rekrut rektor reputace revize revma revolver rezerva riskovat riziko
+robotika rodokmen rohovka rokle rokoko romaneto ropovod ropucha rorejs rosol
+rostlina rotmistr rotoped rotunda roubenka roucho roup roura rovina rovnice
+rozbor rozchod rozdat rozeznat rozhodce rozinka rozjezd rozkaz rozloha
+rozmar rozpad rozruch rozsah roztok rozum rozvod rubrika ruchadlo rukavice
+rukopis ryba rybolov rychlost rydlo rypadlo rytina ryzost sadista sahat sako
+samec samizdat samota sanitka sardinka sasanka satelit sazba sazenice sbor
+schovat sebranka secese sedadlo sediment sedlo sehnat sejmout sekera sekta
+sekunda sekvoje semeno seno servis sesadit seshora seskok seslat sestra
+sesuv sesypat setba setina setkat setnout setrvat sever seznam shoda shrnout
+sifon silnice sirka sirotek sirup situace skafandr skalisko skanzen skaut
+skeptik skica skladba sklenice sklo skluz skoba skokan skoro skripta skrz
+skupina skvost skvrna slabika sladidlo slanina slast slavnost sledovat
+slepec sleva slezina slib slina sliznice slon sloupek slovo sluch sluha
+slunce slupka slza smaragd smetana smilstvo smlouva smog smrad smrk smrtka
+smutek smysl snad snaha snob sobota socha sodovka sokol sopka sotva souboj
+soucit soudce souhlas soulad soumrak souprava soused soutok souviset
+spalovna spasitel spis splav spodek spojenec spolu sponzor spornost spousta
+sprcha spustit sranda sraz srdce srna srnec srovnat srpen srst srub stanice
+starosta statika stavba stehno stezka stodola stolek stopa storno stoupat
+strach stres strhnout strom struna studna stupnice stvol styk subjekt
+subtropy suchar sudost sukno sundat sunout surikata surovina svah svalstvo
+svetr svatba svazek svisle svitek svoboda svodidlo svorka svrab sykavka
+sykot synek synovec sypat sypkost syrovost sysel sytost tabletka tabule
+tahoun tajemno tajfun tajga tajit tajnost taktika tamhle tampon tancovat
+tanec tanker tapeta tavenina tazatel technika tehdy tekutina telefon temnota
+tendence tenista tenor teplota tepna teprve terapie termoska textil ticho
+tiskopis titulek tkadlec tkanina tlapka tleskat tlukot tlupa tmel toaleta
+topinka topol torzo touha toulec tradice traktor tramp trasa traverza trefit
+trest trezor trhavina trhlina trochu trojice troska trouba trpce trpitel
+trpkost trubec truchlit truhlice trus trvat tudy tuhnout tuhost tundra
+turista turnaj tuzemsko tvaroh tvorba tvrdost tvrz tygr tykev ubohost uboze
+ubrat ubrousek ubrus ubytovna ucho uctivost udivit uhradit ujednat ujistit
+ujmout ukazatel uklidnit uklonit ukotvit ukrojit ulice ulita ulovit umyvadlo
+unavit uniforma uniknout upadnout uplatnit uplynout upoutat upravit uran
+urazit usednout usilovat usmrtit usnadnit usnout usoudit ustlat ustrnout
+utahovat utkat utlumit utonout utopenec utrousit uvalit uvolnit uvozovka
+uzdravit uzel uzenina uzlina uznat vagon valcha valoun vana vandal vanilka
+varan varhany varovat vcelku vchod vdova vedro vegetace vejce velbloud
+veletrh velitel velmoc velryba venkov veranda verze veselka veskrze vesnice
+vespodu vesta veterina veverka vibrace vichr videohra vidina vidle vila
+vinice viset vitalita vize vizitka vjezd vklad vkus vlajka vlak vlasec vlevo
+vlhkost vliv vlnovka vloupat vnucovat vnuk voda vodivost vodoznak vodstvo
+vojensky vojna vojsko volant volba volit volno voskovka vozidlo vozovna
+vpravo vrabec vracet vrah vrata vrba vrcholek vrhat vrstva vrtule vsadit
+vstoupit vstup vtip vybavit vybrat vychovat vydat vydra vyfotit vyhledat
+vyhnout vyhodit vyhradit vyhubit vyjasnit vyjet vyjmout vyklopit vykonat
+vylekat vymazat vymezit vymizet vymyslet vynechat vynikat vynutit vypadat
+vyplatit vypravit vypustit vyrazit vyrovnat vyrvat vyslovit vysoko vystavit
+vysunout vysypat vytasit vytesat vytratit vyvinout vyvolat vyvrhel vyzdobit
+vyznat vzadu vzbudit vzchopit vzdor vzduch vzdychat vzestup vzhledem vzkaz
+vzlykat vznik vzorek vzpoura vztah vztek xylofon zabrat zabydlet zachovat
+zadarmo zadusit zafoukat zahltit zahodit zahrada zahynout zajatec zajet
+zajistit zaklepat zakoupit zalepit zamezit zamotat zamyslet zanechat zanikat
+zaplatit zapojit zapsat zarazit zastavit zasunout zatajit zatemnit zatknout
+zaujmout zavalit zavelet zavinit zavolat zavrtat zazvonit zbavit zbrusu
+zbudovat zbytek zdaleka zdarma zdatnost zdivo zdobit zdroj zdvih zdymadlo
+zelenina zeman zemina zeptat zezadu zezdola zhatit zhltnout zhluboka
+zhotovit zhruba zima zimnice zjemnit zklamat zkoumat zkratka zkumavka zlato
+zlehka zloba zlom zlost zlozvyk zmapovat zmar zmatek zmije zmizet zmocnit
+zmodrat zmrzlina zmutovat znak znalost znamenat znovu zobrazit zotavit
+zoubek zoufale zplodit zpomalit zprava zprostit zprudka zprvu zrada zranit
+zrcadlo zrnitost zrno zrovna zrychlit zrzavost zticha ztratit zubovina zubr
+zvednout zvenku zvesela zvon zvrat zvukovod zvyk
+`)
+
+// portugueseWordlist holds the 2048 words of the Portuguese BIP-39 wordlist, indexed by their 11-bit value
+var portugueseWordlist = strings.Fields(`
+abacate abaixo abalar abater abduzir abelha aberto abismo abotoar abranger
+abreviar abrigar abrupto absinto absoluto absurdo abutre acabado acalmar
+acampar acanhar acaso aceitar acelerar acenar acervo acessar acetona achatar
+acidez acima acionado acirrar aclamar aclive acolhida acomodar acoplar
+acordar acumular acusador adaptar adega adentro adepto adequar aderente
+adesivo adeus adiante aditivo adjetivo adjunto admirar adorar adquirir adubo
+adverso advogado aeronave afastar aferir afetivo afinador afivelar aflito
+afluente afrontar agachar agarrar agasalho agenciar agilizar agiota agitado
+agora agradar agreste agrupar aguardar agulha ajoelhar ajudar ajustar
+alameda alarme alastrar alavanca albergue albino alcatra aldeia alecrim
+alegria alertar alface alfinete algum alheio aliar alicate alienar alinhar
+aliviar almofada alocar alpiste alterar altitude alucinar alugar aluno
+alusivo alvo amaciar amador amarelo amassar ambas ambiente ameixa amenizar
+amido amistoso amizade amolador amontoar amoroso amostra amparar ampliar
+ampola anagrama analisar anarquia anatomia andaime anel anexo angular animar
+anjo anomalia anotado ansioso anterior anuidade anunciar anzol apagador
+apalpar apanhado apego apelido apertada apesar apetite apito aplauso
+aplicada apoio apontar aposta aprendiz aprovar aquecer arame aranha arara
+arcada ardente areia arejar arenito aresta argiloso argola arma arquivo
+arraial arrebate arriscar arroba arrumar arsenal arterial artigo arvoredo
+asfaltar asilado aspirar assador assinar assoalho assunto astral atacado
+atadura atalho atarefar atear atender aterro ateu atingir atirador ativo
+atoleiro atracar atrevido atriz atual atum auditor aumentar aura aurora
+autismo autoria autuar avaliar avante avaria avental avesso aviador avisar
+avulso axila azarar azedo azeite azulejo babar babosa bacalhau bacharel
+bacia bagagem baiano bailar baioneta bairro baixista bajular baleia baliza
+balsa banal bandeira banho banir banquete barato barbado baronesa barraca
+barulho baseado bastante batata batedor batida batom batucar baunilha beber
+beijo beirada beisebol beldade beleza belga beliscar bendito bengala benzer
+berimbau berlinda berro besouro bexiga bezerro bico bicudo bienal bifocal
+bifurcar bigorna bilhete bimestre bimotor biologia biombo biosfera bipolar
+birrento biscoito bisneto bispo bissexto bitola bizarro blindado bloco
+bloquear boato bobagem bocado bocejo bochecha boicotar bolada boletim bolha
+bolo bombeiro bonde boneco bonita borbulha borda boreal borracha bovino
+boxeador branco brasa braveza breu briga brilho brincar broa brochura
+bronzear broto bruxo bucha budismo bufar bule buraco busca busto buzina
+cabana cabelo cabide cabo cabrito cacau cacetada cachorro cacique cadastro
+cadeado cafezal caiaque caipira caixote cajado caju calafrio calcular
+caldeira calibrar calmante calota camada cambista camisa camomila campanha
+camuflar canavial cancelar caneta canguru canhoto canivete canoa cansado
+cantar canudo capacho capela capinar capotar capricho captador capuz caracol
+carbono cardeal careca carimbar carneiro carpete carreira cartaz carvalho
+casaco casca casebre castelo casulo catarata cativar caule causador cautelar
+cavalo caverna cebola cedilha cegonha celebrar celular cenoura censo centeio
+cercar cerrado certeiro cerveja cetim cevada chacota chaleira chamado
+chapada charme chatice chave chefe chegada cheiro cheque chicote chifre
+chinelo chocalho chover chumbo chutar chuva cicatriz ciclone cidade cidreira
+ciente cigana cimento cinto cinza ciranda circuito cirurgia citar clareza
+clero clicar clone clube coado coagir cobaia cobertor cobrar cocada coelho
+coentro coeso cogumelo coibir coifa coiote colar coleira colher colidir
+colmeia colono coluna comando combinar comentar comitiva comover complexo
+comum concha condor conectar confuso congelar conhecer conjugar consumir
+contrato convite cooperar copeiro copiador copo coquetel coragem cordial
+corneta coronha corporal correio cortejo coruja corvo cosseno costela
+cotonete couro couve covil cozinha cratera cravo creche credor creme crer
+crespo criada criminal crioulo crise criticar crosta crua cruzeiro cubano
+cueca cuidado cujo culatra culminar culpar cultura cumprir cunhado cupido
+curativo curral cursar curto cuspir custear cutelo damasco datar debater
+debitar deboche debulhar decalque decimal declive decote decretar dedal
+dedicado deduzir defesa defumar degelo degrau degustar deitado deixar
+delator delegado delinear delonga demanda demitir demolido dentista depenado
+depilar depois depressa depurar deriva derramar desafio desbotar descanso
+desenho desfiado desgaste desigual deslize desmamar desova despesa destaque
+desviar detalhar detentor detonar detrito deusa dever devido devotado dezena
+diagrama dialeto didata difuso digitar dilatado diluente diminuir dinastia
+dinheiro diocese direto discreta disfarce disparo disquete dissipar distante
+ditador diurno diverso divisor divulgar dizer dobrador dolorido domador
+dominado donativo donzela dormente dorsal dosagem dourado doutor drenagem
+drible drogaria duelar duende dueto duplo duquesa durante duvidoso eclodir
+ecoar ecologia edificar edital educado efeito efetivar ejetar elaborar
+eleger eleitor elenco elevador eliminar elogiar embargo embolado embrulho
+embutido emenda emergir emissor empatia empenho empinado empolgar emprego
+empurrar emulador encaixe encenado enchente encontro endeusar endossar
+enfaixar enfeite enfim engajado engenho englobar engomado engraxar enguia
+enjoar enlatar enquanto enraizar enrolado enrugar ensaio enseada ensino
+ensopado entanto enteado entidade entortar entrada entulho envergar enviado
+envolver enxame enxerto enxofre enxuto epiderme equipar ereto erguido errata
+erva ervilha esbanjar esbelto escama escola escrita escuta esfinge esfolar
+esfregar esfumado esgrima esmalte espanto espelho espiga esponja espreita
+espumar esquerda estaca esteira esticar estofado estrela estudo esvaziar
+etanol etiqueta euforia europeu evacuar evaporar evasivo eventual evidente
+evoluir exagero exalar examinar exato exausto excesso excitar exclamar
+executar exemplo exibir exigente exonerar expandir expelir expirar explanar
+exposto expresso expulsar externo extinto extrato fabricar fabuloso faceta
+facial fada fadiga faixa falar falta familiar fandango fanfarra fantoche
+fardado farelo farinha farofa farpa fartura fatia fator favorita faxina
+fazenda fechado feijoada feirante felino feminino fenda feno fera feriado
+ferrugem ferver festejar fetal feudal fiapo fibrose ficar ficheiro figurado
+fileira filho filme filtrar firmeza fisgada fissura fita fivela fixador fixo
+flacidez flamingo flanela flechada flora flutuar fluxo focal focinho fofocar
+fogo foguete foice folgado folheto forjar formiga forno forte fosco fossa
+fragata fralda frango frasco fraterno freira frente fretar frieza friso
+fritura fronha frustrar fruteira fugir fulano fuligem fundar fungo funil
+furador furioso futebol gabarito gabinete gado gaiato gaiola gaivota galega
+galho galinha galocha ganhar garagem garfo gargalo garimpo garoupa garrafa
+gasoduto gasto gata gatilho gaveta gazela gelado geleia gelo gemada gemer
+gemido generoso gengiva genial genoma genro geologia gerador germinar gesso
+gestor ginasta gincana gingado girafa girino glacial glicose global glorioso
+goela goiaba golfe golpear gordura gorjeta gorro gostoso goteira governar
+gracejo gradual grafite gralha grampo granada gratuito graveto graxa grego
+grelhar greve grilo grisalho gritaria grosso grotesco grudado grunhido gruta
+guache guarani guaxinim guerrear guiar guincho guisado gula guloso guru
+habitar harmonia haste haver hectare herdar heresia hesitar hiato hibernar
+hidratar hiena hino hipismo hipnose hipoteca hoje holofote homem honesto
+honrado hormonal hospedar humorado iate ideia idoso ignorado igreja iguana
+ileso ilha iludido iluminar ilustrar imagem imediato imenso imersivo
+iminente imitador imortal impacto impedir implante impor imprensa impune
+imunizar inalador inapto inativo incenso inchar incidir incluir incolor
+indeciso indireto indutor ineficaz inerente infantil infestar infinito
+inflamar informal infrator ingerir inibido inicial inimigo injetar inocente
+inodoro inovador inox inquieto inscrito inseto insistir inspetor instalar
+insulto intacto integral intimar intocado intriga invasor inverno invicto
+invocar iogurte iraniano ironizar irreal irritado isca isento isolado
+isqueiro italiano janeiro jangada janta jararaca jardim jarro jasmim jato
+javali jazida jejum joaninha joelhada jogador joia jornal jorrar jovem juba
+judeu judoca juiz julgador julho jurado jurista juro justa labareda laboral
+lacre lactante ladrilho lagarta lagoa laje lamber lamentar laminar lampejo
+lanche lapidar lapso laranja lareira largura lasanha lastro lateral latido
+lavanda lavoura lavrador laxante lazer lealdade lebre legado legendar
+legista leigo leiloar leitura lembrete leme lenhador lentilha leoa lesma
+leste letivo letreiro levar leveza levitar liberal libido liderar ligar
+ligeiro limitar limoeiro limpador linda linear linhagem liquidez listagem
+lisura litoral livro lixa lixeira locador locutor lojista lombo lona longe
+lontra lorde lotado loteria loucura lousa louvar luar lucidez lucro luneta
+lustre lutador luva macaco macete machado macio madeira madrinha magnata
+magreza maior mais malandro malha malote maluco mamilo mamoeiro mamute
+manada mancha mandato manequim manhoso manivela manobrar mansa manter
+manusear mapeado maquinar marcador maresia marfim margem marinho marmita
+maroto marquise marreco martelo marujo mascote masmorra massagem mastigar
+matagal materno matinal matutar maxilar medalha medida medusa megafone meiga
+melancia melhor membro memorial menino menos mensagem mental merecer
+mergulho mesada mesclar mesmo mesquita mestre metade meteoro metragem mexer
+mexicano micro migalha migrar milagre milenar milhar mimado minerar minhoca
+ministro minoria miolo mirante mirtilo misturar mocidade moderno modular
+moeda moer moinho moita moldura moleza molho molinete molusco montanha
+moqueca morango morcego mordomo morena mosaico mosquete mostarda motel motim
+moto motriz muda muito mulata mulher multar mundial munido muralha murcho
+muscular museu musical nacional nadador naja namoro narina narrado nascer
+nativa natureza navalha navegar navio neblina nebuloso negativa negociar
+negrito nervoso neta neural nevasca nevoeiro ninar ninho nitidez nivelar
+nobreza noite noiva nomear nominal nordeste nortear notar noticiar noturno
+novelo novilho novo nublado nudez numeral nupcial nutrir nuvem obcecado
+obedecer objetivo obrigado obscuro obstetra obter obturar ocidente ocioso
+ocorrer oculista ocupado ofegante ofensiva oferenda oficina ofuscado ogiva
+olaria oleoso olhar oliveira ombro omelete omisso omitir ondulado oneroso
+ontem opcional operador oponente oportuno oposto orar orbitar ordem ordinal
+orfanato orgasmo orgulho oriental origem oriundo orla ortodoxo orvalho
+oscilar ossada osso ostentar otimismo ousadia outono outubro ouvido ovelha
+ovular oxidar oxigenar pacato paciente pacote pactuar padaria padrinho pagar
+pagode painel pairar paisagem palavra palestra palheta palito palmada
+palpitar pancada panela panfleto panqueca pantanal papagaio papelada papiro
+parafina parcial pardal parede partida pasmo passado pastel patamar patente
+patinar patrono paulada pausar peculiar pedalar pedestre pediatra pedra
+pegada peitoral peixe pele pelicano penca pendurar peneira penhasco pensador
+pente perceber perfeito pergunta perito permitir perna perplexo persiana
+pertence peruca pescado pesquisa pessoa petiscar piada picado piedade
+pigmento pilastra pilhado pilotar pimenta pincel pinguim pinha pinote pintar
+pioneiro pipoca piquete piranha pires pirueta piscar pistola pitanga pivete
+planta plaqueta platina plebeu plumagem pluvial pneu poda poeira poetisa
+polegada policiar poluente polvilho pomar pomba ponderar pontaria populoso
+porta possuir postal pote poupar pouso povoar praia prancha prato praxe
+prece predador prefeito premiar prensar preparar presilha pretexto prevenir
+prezar primata princesa prisma privado processo produto profeta proibido
+projeto prometer propagar prosa protetor provador publicar pudim pular
+pulmonar pulseira punhal punir pupilo pureza puxador quadra quantia quarto
+quase quebrar queda queijo quente querido quimono quina quiosque rabanada
+rabisco rachar racionar radial raiar rainha raio raiva rajada ralado ramal
+ranger ranhura rapadura rapel rapidez raposa raquete raridade rasante
+rascunho rasgar raspador rasteira rasurar ratazana ratoeira realeza reanimar
+reaver rebaixar rebelde rebolar recado recente recheio recibo recordar
+recrutar recuar rede redimir redonda reduzida reenvio refinar refletir
+refogar refresco refugiar regalia regime regra reinado reitor rejeitar
+relativo remador remendo remorso renovado reparo repelir repleto repolho
+represa repudiar requerer resenha resfriar resgatar residir resolver
+respeito ressaca restante resumir retalho reter retirar retomada retratar
+revelar revisor revolta riacho rica rigidez rigoroso rimar ringue risada
+risco risonho robalo rochedo rodada rodeio rodovia roedor roleta romano
+roncar rosado roseira rosto rota roteiro rotina rotular rouco roupa roxo
+rubro rugido rugoso ruivo rumo rupestre russo sabor saciar sacola sacudir
+sadio safira saga sagrada saibro salada saleiro salgado saliva salpicar
+salsicha saltar salvador sambar samurai sanar sanfona sangue sanidade sapato
+sarda sargento sarjeta saturar saudade saxofone sazonal secar secular seda
+sedento sediado sedoso sedutor segmento segredo segundo seiva seleto
+selvagem semanal semente senador senhor sensual sentado separado sereia
+seringa serra servo setembro setor sigilo silhueta silicone simetria
+simpatia simular sinal sincero singular sinopse sintonia sirene siri situado
+soberano sobra socorro sogro soja solda soletrar solteiro sombrio sonata
+sondar sonegar sonhador sono soprano soquete sorrir sorteio sossego sotaque
+soterrar sovado sozinho suavizar subida submerso subsolo subtrair sucata
+sucesso suco sudeste sufixo sugador sugerir sujeito sulfato sumir suor
+superior suplicar suposto suprimir surdina surfista surpresa surreal surtir
+suspiro sustento tabela tablete tabuada tacho tagarela talher talo talvez
+tamanho tamborim tampa tangente tanto tapar tapioca tardio tarefa tarja
+tarraxa tatuagem taurino taxativo taxista teatral tecer tecido teclado
+tedioso teia teimar telefone telhado tempero tenente tensor tentar termal
+terno terreno tese tesoura testado teto textura texugo tiara tigela tijolo
+timbrar timidez tingido tinteiro tiragem titular toalha tocha tolerar tolice
+tomada tomilho tonel tontura topete tora torcido torneio torque torrada
+torto tostar touca toupeira toxina trabalho tracejar tradutor trafegar
+trajeto trama trancar trapo traseiro tratador travar treino tremer trepidar
+trevo triagem tribo triciclo tridente trilogia trindade triplo triturar
+triunfal trocar trombeta trova trunfo truque tubular tucano tudo tulipa tupi
+turbo turma turquesa tutelar tutorial uivar umbigo unha unidade uniforme
+urologia urso urtiga urubu usado usina usufruir vacina vadiar vagaroso
+vaidoso vala valente validade valores vantagem vaqueiro varanda vareta
+varrer vascular vasilha vassoura vazar vazio veado vedar vegetar veicular
+veleiro velhice veludo vencedor vendaval venerar ventre verbal verdade
+vereador vergonha vermelho verniz versar vertente vespa vestido vetorial
+viaduto viagem viajar viatura vibrador videira vidraria viela viga vigente
+vigiar vigorar vilarejo vinco vinheta vinil violeta virada virtude visitar
+visto vitral viveiro vizinho voador voar vogal volante voleibol voltagem
+volumoso vontade vulto vuvuzela xadrez xarope xeque xeretar xerife xingar
+zangado zarpar zebu zelador zombar zoologia zumbido
+`)
@@ -0,0 +1,170 @@
+package model
+
+import (
+	"encoding/hex"
+	"fmt"
+	"vault-hd-wallet/utils"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// Chains an account can be derived for
+const (
+	ChainEthereum = "ethereum"
+	ChainBitcoin  = "bitcoin"
+)
+
+// Bitcoin address types and the BIP defining their derivation purpose
+const (
+	AddressTypeP2PKH      = "p2pkh"       // BIP-44
+	AddressTypeP2SHP2WPKH = "p2sh-p2wpkh" // BIP-49
+	AddressTypeP2WPKH     = "p2wpkh"      // BIP-84
+	AddressTypeP2TR       = "p2tr"        // BIP-86
+)
+
+// Bitcoin networks
+const (
+	NetworkMainnet = "mainnet"
+	NetworkTestnet = "testnet"
+	NetworkRegtest = "regtest"
+)
+
+var bitcoinPurposes = map[string]uint32{
+	AddressTypeP2PKH:      44,
+	AddressTypeP2SHP2WPKH: 49,
+	AddressTypeP2WPKH:     84,
+	AddressTypeP2TR:       86,
+}
+
+// BitcoinNetworkParams returns the chain parameters of the network
+func BitcoinNetworkParams(network string) (*chaincfg.Params, error) {
+	switch network {
+	case NetworkMainnet:
+		return &chaincfg.MainNetParams, nil
+	case NetworkTestnet:
+		return &chaincfg.TestNet3Params, nil
+	case NetworkRegtest:
+		return &chaincfg.RegressionNetParams, nil
+	}
+	return nil, fmt.Errorf("unsupported bitcoin network %s", network)
+}
+
+// DefaultBitcoinDerivationPath returns the first receiving address path of the
+// first account for the address type, e.g. m/84'/0'/0'/0/0.
+func DefaultBitcoinDerivationPath(addressType string, network string) (string, error) {
+	purpose, ok := bitcoinPurposes[addressType]
+	if !ok {
+		return "", fmt.Errorf("unsupported bitcoin address type %s", addressType)
+	}
+
+	params, err := BitcoinNetworkParams(network)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("m/%d'/%d'/0'/0/0", purpose, params.HDCoinType), nil
+}
+
+// BitcoinAddress encodes the public key as an address of the type on the network
+func BitcoinAddress(publicKey *btcec.PublicKey, addressType string, params *chaincfg.Params) (string, error) {
+	pubKeyHash := btcutil.Hash160(publicKey.SerializeCompressed())
+
+	switch addressType {
+	case AddressTypeP2PKH:
+		address, err := btcutil.NewAddressPubKeyHash(pubKeyHash, params)
+		if err != nil {
+			return "", err
+		}
+		return address.EncodeAddress(), nil
+	case AddressTypeP2SHP2WPKH:
+		redeemScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(pubKeyHash).Script()
+		if err != nil {
+			return "", err
+		}
+		address, err := btcutil.NewAddressScriptHash(redeemScript, params)
+		if err != nil {
+			return "", err
+		}
+		return address.EncodeAddress(), nil
+	case AddressTypeP2WPKH:
+		return utils.EncodeSegWitAddress(params.Bech32HRPSegwit, 0, pubKeyHash)
+	case AddressTypeP2TR:
+		outputKey, err := TaprootOutputKey(publicKey, nil)
+		if err != nil {
+			return "", err
+		}
+		return utils.EncodeSegWitAddress(params.Bech32HRPSegwit, 1, outputKey)
+	}
+
+	return "", fmt.Errorf("unsupported bitcoin address type %s", addressType)
+}
+
+// DeriveBitcoin derives a bitcoin account of the address type on the network
+func (w *Wallet) DeriveBitcoin(path accounts.DerivationPath, addressType string, network string) (*Account, error) {
+	params, err := BitcoinNetworkParams(network)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := w.deriveExtendedKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := BitcoinAddress(privateKey.PubKey(), addressType, params)
+	if err != nil {
+		return nil, err
+	}
+
+	URL := accounts.URL{
+		Scheme: "",
+		Path:   path.String(),
+	}
+
+	return &Account{
+		Address:     address,
+		URL:         URL.String(),
+		PrivateKey:  hex.EncodeToString(privateKey.Serialize()),
+		PublicKey:   hex.EncodeToString(privateKey.PubKey().SerializeCompressed()),
+		Chain:       ChainBitcoin,
+		AddressType: addressType,
+		Network:     network,
+	}, nil
+}
+
+// ScriptAddress returns the address the output script pays to on the network, empty for non-standard scripts
+func ScriptAddress(script []byte, params *chaincfg.Params) string {
+	scriptType, program := ClassifyScript(script)
+
+	var address string
+	var err error
+	switch scriptType {
+	case ScriptTypeP2PKH:
+		var decoded *btcutil.AddressPubKeyHash
+		if decoded, err = btcutil.NewAddressPubKeyHash(program, params); err == nil {
+			address = decoded.EncodeAddress()
+		}
+	case ScriptTypeP2SH:
+		var decoded *btcutil.AddressScriptHash
+		if decoded, err = btcutil.NewAddressScriptHashFromHash(program, params); err == nil {
+			address = decoded.EncodeAddress()
+		}
+	case ScriptTypeP2WPKH, ScriptTypeP2WSH:
+		address, err = utils.EncodeSegWitAddress(params.Bech32HRPSegwit, 0, program)
+	case ScriptTypeP2TR:
+		address, err = utils.EncodeSegWitAddress(params.Bech32HRPSegwit, 1, program)
+	}
+	if err != nil {
+		return ""
+	}
+	return address
+}
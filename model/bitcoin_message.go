@@ -0,0 +1,243 @@
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// Bitcoin message signature formats
+const (
+	MessageFormatBIP137 = "bip137"
+	MessageFormatBIP322 = "bip322"
+)
+
+const bitcoinMessagePrefix = "Bitcoin Signed Message:\n"
+
+// BIP-137 header bytes of compressed keys, before adding the recovery ID
+var bip137Headers = map[string]byte{
+	AddressTypeP2PKH:      31,
+	AddressTypeP2SHP2WPKH: 35,
+	AddressTypeP2WPKH:     39,
+}
+
+// BitcoinMessageHash returns the double SHA-256 of the message with the Bitcoin Signed Message prefix
+func BitcoinMessageHash(message string) []byte {
+	var buf bytes.Buffer
+	wire.WriteVarString(&buf, 0, bitcoinMessagePrefix)
+	wire.WriteVarString(&buf, 0, message)
+	return chainhash.DoubleHashB(buf.Bytes())
+}
+
+// SignBIP137Message returns the base64 BIP-137 compact signature of the message
+// with the header byte of the address type
+func SignBIP137Message(privateKey *btcec.PrivateKey, addressType string, message string) (string, error) {
+	header, ok := bip137Headers[addressType]
+	if !ok {
+		return "", fmt.Errorf("BIP-137 does not support %s addresses", addressType)
+	}
+
+	signature, err := btcec.SignCompact(btcec.S256(), privateKey, BitcoinMessageHash(message), true)
+	if err != nil {
+		return "", err
+	}
+
+	// SignCompact sets the header of a compressed P2PKH key, 31 plus the recovery ID
+	signature[0] = header + signature[0] - bip137Headers[AddressTypeP2PKH]
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifyBIP137Message checks a BIP-137 signature of the message against the
+// address. Signatures of segwit addresses using the P2PKH header, as produced
+// by some wallets, are accepted as well.
+func VerifyBIP137Message(address string, addressType string, params *chaincfg.Params, message string, signature string) (bool, error) {
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(decoded) != 65 {
+		return false, errors.New("invalid BIP-137 signature")
+	}
+
+	header := decoded[0]
+	if header < 27 || header > 42 {
+		return false, fmt.Errorf("invalid BIP-137 header %d", header)
+	}
+	if header < bip137Headers[AddressTypeP2PKH] {
+		// uncompressed keys are never derived by the wallet
+		return false, nil
+	}
+	headerType := AddressTypeP2PKH
+	for candidate, first := range bip137Headers {
+		if header >= first && header < first+4 {
+			headerType = candidate
+		}
+	}
+	if headerType != AddressTypeP2PKH && headerType != addressType {
+		return false, nil
+	}
+
+	compact := append([]byte{27 + 4 + (header-27)%4}, decoded[1:]...)
+	publicKey, compressed, err := btcec.RecoverCompact(btcec.S256(), compact, BitcoinMessageHash(message))
+	if err != nil || !compressed {
+		return false, nil
+	}
+
+	recovered, err := BitcoinAddress(publicKey, addressType, params)
+	if err != nil {
+		return false, err
+	}
+	return recovered == address, nil
+}
+
+// bip322Transactions returns the BIP-322 to_spend transaction committing to
+// the message and the unsigned to_sign transaction spending it
+func bip322Transactions(pkScript []byte, message string) (*wire.MsgTx, *wire.MsgTx, error) {
+	messageHash := TaggedHash("BIP0322-signed-message", []byte(message))
+	scriptSig, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(messageHash).Script()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toSpend := wire.NewMsgTx(0)
+	toSpend.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: 0xffffffff},
+		SignatureScript:  scriptSig,
+		Sequence:         0,
+	})
+	toSpend.AddTxOut(wire.NewTxOut(0, pkScript))
+
+	toSign := wire.NewMsgTx(0)
+	toSign.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: toSpend.TxHash(), Index: 0},
+		Sequence:         0,
+	})
+	toSign.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_RETURN}))
+
+	return toSpend, toSign, nil
+}
+
+// bip322Packet wraps the to_sign transaction into a PSBT carrying the whole
+// to_spend transaction, which segwit v0 signing requires, and its output
+func bip322Packet(toSpend *wire.MsgTx, toSign *wire.MsgTx) (*PSBT, error) {
+	var nonWitnessUTXO bytes.Buffer
+	if err := toSpend.SerializeNoWitness(&nonWitnessUTXO); err != nil {
+		return nil, err
+	}
+	var witnessUTXO bytes.Buffer
+	if err := wire.WriteTxOut(&witnessUTXO, 0, 0, toSpend.TxOut[0]); err != nil {
+		return nil, err
+	}
+	return &PSBT{
+		UnsignedTx: toSign,
+		Inputs: []PSBTMap{{
+			{Key: []byte{PSBTInNonWitnessUTXO}, Value: nonWitnessUTXO.Bytes()},
+			{Key: []byte{PSBTInWitnessUTXO}, Value: witnessUTXO.Bytes()},
+		}},
+		Outputs: []PSBTMap{{}},
+	}, nil
+}
+
+// SignBIP322Message returns the base64 BIP-322 simple signature of the message,
+// the witness of the to_sign transaction spending the output script
+func SignBIP322Message(privateKey *btcec.PrivateKey, pkScript []byte, message string) (string, error) {
+	scriptType, _ := ClassifyScript(pkScript)
+	if scriptType != ScriptTypeP2WPKH && scriptType != ScriptTypeP2TR {
+		return "", fmt.Errorf("BIP-322 simple signatures do not support %s outputs", scriptType)
+	}
+
+	toSpend, toSign, err := bip322Transactions(pkScript, message)
+	if err != nil {
+		return "", err
+	}
+
+	packet, err := bip322Packet(toSpend, toSign)
+	if err != nil {
+		return "", err
+	}
+
+	if _, signed, err := packet.SignInput(0, privateKey, false); err != nil || !signed {
+		if err == nil {
+			err = errors.New("the key does not match the address")
+		}
+		return "", err
+	}
+	if finalized, err := packet.FinalizeInput(0, privateKey.PubKey()); err != nil || !finalized {
+		if err == nil {
+			err = errors.New("fail to finalize the BIP-322 signature")
+		}
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(packet.Inputs[0].Get(PSBTInFinalScriptWitness)), nil
+}
+
+// VerifyBIP322Message checks a BIP-322 simple signature of the message against the output script
+func VerifyBIP322Message(pkScript []byte, message string, signature string) (bool, error) {
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false, errors.New("invalid BIP-322 signature")
+	}
+	witness, err := readWitness(decoded)
+	if err != nil {
+		return false, errors.New("invalid BIP-322 signature")
+	}
+
+	toSpend, toSign, err := bip322Transactions(pkScript, message)
+	if err != nil {
+		return false, err
+	}
+
+	scriptType, program := ClassifyScript(pkScript)
+	switch scriptType {
+	case ScriptTypeP2WPKH:
+		if len(witness) != 2 || len(witness[0]) == 0 || !bytes.Equal(btcutil.Hash160(witness[1]), program) {
+			return false, nil
+		}
+		signatureBytes, hashType := witness[0][:len(witness[0])-1], witness[0][len(witness[0])-1]
+		if txscript.SigHashType(hashType) != txscript.SigHashAll {
+			return false, nil
+		}
+		publicKey, err := btcec.ParsePubKey(witness[1], btcec.S256())
+		if err != nil {
+			return false, nil
+		}
+		parsed, err := btcec.ParseDERSignature(signatureBytes, btcec.S256())
+		if err != nil {
+			return false, nil
+		}
+		sigHash, err := txscript.CalcWitnessSigHash(pkScript, txscript.NewTxSigHashes(toSign), txscript.SigHashAll, toSign, 0, 0)
+		if err != nil {
+			return false, err
+		}
+		return parsed.Verify(sigHash, publicKey), nil
+	case ScriptTypeP2TR:
+		if len(witness) != 1 || (len(witness[0]) != 64 && len(witness[0]) != 65) {
+			return false, nil
+		}
+		hashType := uint32(sigHashDefault)
+		if len(witness[0]) == 65 {
+			hashType = uint32(witness[0][64])
+			if hashType != uint32(txscript.SigHashAll) {
+				return false, nil
+			}
+		}
+
+		packet, err := bip322Packet(toSpend, toSign)
+		if err != nil {
+			return false, err
+		}
+		sigHash, err := packet.TaprootSigHash(0, hashType)
+		if err != nil {
+			return false, err
+		}
+		return SchnorrVerify(program, sigHash, witness[0][:64]), nil
+	}
+
+	return false, fmt.Errorf("BIP-322 simple signatures do not support %s outputs", scriptType)
+}
@@ -0,0 +1,195 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+)
+
+// BIP-322 signatures of P2WPKH addresses, the default format of the type,
+// sign the to_spend transaction as a verified non-witness utxo
+func TestSignBIP322MessageP2WPKH(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := wallet.DeriveBitcoin(MustParseDerivationPath("m/84'/0'/0'/0/0"), AddressTypeP2WPKH, NetworkMainnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, account.PrivateKey))
+	pkScript, err := PayToPublicKeyScript(privateKey.PubKey(), AddressTypeP2WPKH)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature, err := SignBIP322Message(privateKey, pkScript, "Hello World")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := VerifyBIP322Message(pkScript, "Hello World", signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid {
+		t.Error("the BIP-322 signature does not verify")
+	}
+
+	valid, err = VerifyBIP322Message(pkScript, "Hello World!", signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid {
+		t.Error("the BIP-322 signature verifies another message")
+	}
+}
+
+// the test vectors of BIP-322, signed with the key of bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l
+func TestBIP322Vectors(t *testing.T) {
+	wif, err := btcutil.DecodeWIF("L3VFeEujGtevx9w18HD1fhRbCH67Az2dpCymeRE1SoPK6XQtaN2k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := BitcoinAddress(wif.PrivKey.PubKey(), AddressTypeP2WPKH, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l" {
+		t.Fatalf("got address %s", address)
+	}
+	pkScript, err := PayToPublicKeyScript(wif.PrivKey.PubKey(), AddressTypeP2WPKH)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vectors := []struct {
+		message     string
+		messageHash string
+		signature   string
+	}{
+		{"", "c90c269c4f8fcbe6880f72a721ddfbf1914268a794cbb21cfafee13770ae19f1", "AkcwRAIgM2gBAQqvZX15ZiysmKmQpDrG83avLIT492QBzLnQIxYCIBaTpOaD20qRlEylyxFSeEA2ba9YOixpX8z46TSDtS40ASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI="},
+		{"Hello World", "f0eb03b1a75ac6d9847f55c624a99169b5dccba2a31f5b23bea77ba270de0a7a", "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI="},
+	}
+
+	for _, vector := range vectors {
+		if messageHash := hex.EncodeToString(TaggedHash("BIP0322-signed-message", []byte(vector.message))); messageHash != vector.messageHash {
+			t.Errorf("%q: got message hash %s, want %s", vector.message, messageHash, vector.messageHash)
+		}
+
+		valid, err := VerifyBIP322Message(pkScript, vector.message, vector.signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !valid {
+			t.Errorf("%q: the reference signature does not verify", vector.message)
+		}
+
+		signature, err := SignBIP322Message(wif.PrivKey, pkScript, vector.message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid, err = VerifyBIP322Message(pkScript, vector.message, signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !valid {
+			t.Errorf("%q: the signature does not verify", vector.message)
+		}
+	}
+
+	// the signature of one message does not verify the other
+	valid, err := VerifyBIP322Message(pkScript, "Hello World", vectors[0].signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid {
+		t.Error("the signature of the empty message verifies Hello World")
+	}
+}
+
+// BIP-137 signatures carry the header of each address type and verify
+// against the address of the signing key only
+func TestBIP137RoundTrip(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vectors := []struct {
+		path        string
+		addressType string
+		header      byte
+	}{
+		{"m/44'/0'/0'/0/0", AddressTypeP2PKH, 31},
+		{"m/49'/0'/0'/0/0", AddressTypeP2SHP2WPKH, 35},
+		{"m/84'/0'/0'/0/0", AddressTypeP2WPKH, 39},
+	}
+
+	for _, vector := range vectors {
+		account, err := wallet.DeriveBitcoin(MustParseDerivationPath(vector.path), vector.addressType, NetworkMainnet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, account.PrivateKey))
+
+		signature, err := SignBIP137Message(privateKey, vector.addressType, "Hello World")
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header := decoded[0]; header < vector.header || header >= vector.header+4 {
+			t.Errorf("%s: got header %d, want %d to %d", vector.addressType, header, vector.header, vector.header+3)
+		}
+
+		valid, err := VerifyBIP137Message(account.Address, vector.addressType, &chaincfg.MainNetParams, "Hello World", signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !valid {
+			t.Errorf("%s: the signature does not verify", vector.addressType)
+		}
+
+		valid, err = VerifyBIP137Message(account.Address, vector.addressType, &chaincfg.MainNetParams, "Hello World!", signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if valid {
+			t.Errorf("%s: the signature verifies another message", vector.addressType)
+		}
+	}
+}
+
+// segwit addresses accept the P2PKH header some wallets sign with, but not the header of another segwit type
+func TestBIP137SegwitHeaders(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := wallet.DeriveBitcoin(MustParseDerivationPath("m/84'/0'/0'/0/0"), AddressTypeP2WPKH, NetworkMainnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, account.PrivateKey))
+
+	for addressType, want := range map[string]bool{AddressTypeP2PKH: true, AddressTypeP2SHP2WPKH: false} {
+		signature, err := SignBIP137Message(privateKey, addressType, "Hello World")
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid, err := VerifyBIP137Message(account.Address, AddressTypeP2WPKH, &chaincfg.MainNetParams, "Hello World", signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if valid != want {
+			t.Errorf("a %s header verifies %v for a p2wpkh address, want %v", addressType, valid, want)
+		}
+	}
+}
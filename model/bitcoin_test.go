@@ -0,0 +1,81 @@
+package model
+
+import "testing"
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// BIP-44, BIP-49, BIP-84 and BIP-86 addresses of the "abandon ... about" mnemonic
+func TestDeriveBitcoin(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vectors := []struct {
+		path        string
+		addressType string
+		address     string
+	}{
+		{"m/44'/0'/0'/0/0", AddressTypeP2PKH, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+		{"m/49'/0'/0'/0/0", AddressTypeP2SHP2WPKH, "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"},
+		{"m/84'/0'/0'/0/0", AddressTypeP2WPKH, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{"m/84'/0'/0'/0/1", AddressTypeP2WPKH, "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"},
+		{"m/84'/0'/0'/1/0", AddressTypeP2WPKH, "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el"},
+		{"m/86'/0'/0'/0/0", AddressTypeP2TR, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+		{"m/86'/0'/0'/0/1", AddressTypeP2TR, "bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh"},
+		{"m/86'/0'/0'/1/0", AddressTypeP2TR, "bc1p3qkhfews2uk44qtvauqyr2ttdsw7svhkl9nkm9s9c3x4ax5h60wqwruhk7"},
+	}
+
+	for _, vector := range vectors {
+		account, err := wallet.DeriveBitcoin(MustParseDerivationPath(vector.path), vector.addressType, NetworkMainnet)
+		if err != nil {
+			t.Errorf("%s: %v", vector.path, err)
+			continue
+		}
+		if account.Address != vector.address {
+			t.Errorf("%s: address %s, expected %s", vector.path, account.Address, vector.address)
+		}
+	}
+}
+
+func TestDefaultBitcoinDerivationPath(t *testing.T) {
+	path, err := DefaultBitcoinDerivationPath(AddressTypeP2TR, NetworkTestnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "m/86'/1'/0'/0/0" {
+		t.Errorf("path %s", path)
+	}
+}
+
+// BIP-84 account keys of the "abandon ... about" mnemonic, whose descriptor key
+// uses the xpub or tpub serialization whatever the format
+func TestDeriveExtendedPublicKey(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vectors := []struct {
+		path          string
+		network       string
+		key           string
+		descriptorKey string
+	}{
+		{"m/84'/0'/0'", NetworkMainnet, "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs", "[73c5da0a/84'/0'/0']xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V"},
+		{"m/84'/1'/0'", NetworkTestnet, "vpub5Y6cjg78GGuNLsaPhmYsiw4gYX3HoQiRBiSwDaBXKUafCt9bNwWQiitDk5VZ5BVxYnQdwoTyXSs2JHRPAgjAvtbBrf8ZhDYe2jWAqvZVnsc", "[73c5da0a/84'/1'/0']tpubDC8msFGeGuwnKG9Upg7DM2b4DaRqg3CUZa5g8v2SRQ6K4NSkxUgd7HsL2XVWbVm39yBA4LAxysQAm397zwQSQoQgewGiYZqrA9DsP4zbQ1M"},
+	}
+
+	for _, vector := range vectors {
+		key, err := wallet.DeriveExtendedPublicKey(MustParseDerivationPath(vector.path), ZpubFormat, vector.network)
+		if err != nil {
+			t.Fatalf("%s: %v", vector.path, err)
+		}
+		if key.Key != vector.key {
+			t.Errorf("%s: got key %s, want %s", vector.path, key.Key, vector.key)
+		}
+		if key.DescriptorKey() != vector.descriptorKey {
+			t.Errorf("%s: got descriptor key %s, want %s", vector.path, key.DescriptorKey(), vector.descriptorKey)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// CeremonyPath is the storage path of the key ceremony
+const CeremonyPath = "ceremony"
+
+// CeremonySharesPrefix is the storage prefix of the backup shares awaiting
+// delivery to the participants of a generate ceremony
+const CeremonySharesPrefix = "ceremony-shares/"
+
+// Modes of the key ceremony
+const (
+	// CeremonyGenerate combines the entropy contributed by the operators into a new seed
+	CeremonyGenerate = "generate"
+	// CeremonyRestore recovers the seed from the SLIP-39 shares contributed by the operators
+	CeremonyRestore = "restore"
+)
+
+// MinCeremonyEntropyBytes is the minimum size of an entropy contribution
+const MinCeremonyEntropyBytes = 16
+
+// CeremonyParticipant records an operator who contributed to the ceremony
+type CeremonyParticipant struct {
+	EntityID      string    `json:"entityID"`
+	DisplayName   string    `json:"displayName"`
+	ContributedAt time.Time `json:"contributedAt"`
+
+	// Share names the backup share awaiting delivery to the operator
+	Share string `json:"share,omitempty"`
+}
+
+// Ceremony gathers the contributions of several operators to a wallet, so
+// that no single operator ever controls the seed material
+type Ceremony struct {
+	Mode string `json:"mode"`
+
+	// Threshold is the number of entropy contributions generating the seed, or
+	// the number of operators contributing shares before a seed is restored
+	Threshold int `json:"threshold,omitempty"`
+
+	// BackupThreshold is the number of backup shares recovering the generated seed
+	BackupThreshold int    `json:"backupThreshold,omitempty"`
+	SharePassphrase string `json:"sharePassphrase,omitempty"`
+
+	// Entropy is the contribution of the plugin itself, so that the operators
+	// cannot choose the seed even together
+	Entropy       string   `json:"entropy,omitempty"`
+	Contributions []string `json:"contributions,omitempty"`
+
+	Participants []CeremonyParticipant `json:"participants"`
+	StartedAt    time.Time             `json:"startedAt"`
+	CompletedAt  *time.Time            `json:"completedAt,omitempty"`
+}
+
+// ReadCeremony returns the key ceremony, or nil if none was started
+func ReadCeremony(ctx context.Context, storage logical.Storage) (*Ceremony, error) {
+	entry, err := storage.Get(ctx, CeremonyPath)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var ceremony *Ceremony
+	err = entry.DecodeJSON(&ceremony)
+	if err != nil {
+		return nil, errors.New("Fail to decode ceremony to JSON format")
+	}
+
+	return ceremony, nil
+}
+
+// Validate checks the parameters of the ceremony
+func (c *Ceremony) Validate() error {
+	switch c.Mode {
+	case CeremonyGenerate:
+		if c.Threshold < 2 || c.Threshold > slip39MaxShareCount {
+			return fmt.Errorf("invalid threshold %d, must be between 2 and %d so that no single operator generates the seed", c.Threshold, slip39MaxShareCount)
+		}
+		if c.BackupThreshold < 2 || c.BackupThreshold > c.Threshold {
+			return fmt.Errorf("invalid backup_threshold %d, must be between 2 and the threshold %d so that no single backup share holds the seed", c.BackupThreshold, c.Threshold)
+		}
+	case CeremonyRestore:
+		if c.Threshold < 2 || c.Threshold > slip39MaxShareCount {
+			return fmt.Errorf("invalid threshold %d, must be between 2 and %d so that no single operator restores the seed", c.Threshold, slip39MaxShareCount)
+		}
+	default:
+		return fmt.Errorf("unsupported ceremony mode %s", c.Mode)
+	}
+
+	return validSLIP39Passphrase(c.SharePassphrase)
+}
+
+// Completed reports whether the seed was produced
+func (c *Ceremony) Completed() bool {
+	return c.CompletedAt != nil
+}
+
+// Participant returns the participant of the entity, or nil if it did not contribute
+func (c *Ceremony) Participant(entityID string) *CeremonyParticipant {
+	for i := range c.Participants {
+		if c.Participants[i].EntityID == entityID {
+			return &c.Participants[i]
+		}
+	}
+	return nil
+}
+
+// CombineEntropy hashes the entropy of the plugin and of every operator into
+// BIP-39 entropy of the bits, which no contributor alone can predict
+func (c *Ceremony) CombineEntropy(bits int) ([]byte, error) {
+	hash := sha256.New()
+	for _, contribution := range append([]string{c.Entropy}, c.Contributions...) {
+		entropy, err := hex.DecodeString(contribution)
+		if err != nil {
+			return nil, errors.New("Fail to decode the ceremony entropy")
+		}
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(entropy)))
+		hash.Write(length[:])
+		hash.Write(entropy)
+	}
+
+	return hash.Sum(nil)[:bits/8], nil
+}
+
+// Complete drops the secrets of the ceremony once the seed is produced,
+// keeping the record of its participants
+func (c *Ceremony) Complete() {
+	now := time.Now()
+	c.CompletedAt = &now
+	c.Entropy = ""
+	c.Contributions = nil
+	c.SharePassphrase = ""
+}
@@ -0,0 +1,26 @@
+package model
+
+import "testing"
+
+// no single operator or backup share may hold the seed of a ceremony
+func TestCeremonyValidate(t *testing.T) {
+	vectors := []struct {
+		ceremony Ceremony
+		valid    bool
+	}{
+		{Ceremony{Mode: CeremonyGenerate, Threshold: 3, BackupThreshold: 2}, true},
+		{Ceremony{Mode: CeremonyGenerate, Threshold: 2, BackupThreshold: 2}, true},
+		{Ceremony{Mode: CeremonyGenerate, Threshold: 1, BackupThreshold: 1}, false},
+		{Ceremony{Mode: CeremonyGenerate, Threshold: 3, BackupThreshold: 1}, false},
+		{Ceremony{Mode: CeremonyGenerate, Threshold: 2, BackupThreshold: 3}, false},
+		{Ceremony{Mode: CeremonyRestore, Threshold: 2}, true},
+		{Ceremony{Mode: CeremonyRestore, Threshold: 1}, false},
+	}
+
+	for _, vector := range vectors {
+		err := vector.ceremony.Validate()
+		if (err == nil) != vector.valid {
+			t.Errorf("%s with threshold %d and backup_threshold %d: got error %v", vector.ceremony.Mode, vector.ceremony.Threshold, vector.ceremony.BackupThreshold, err)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// ChainProfilesPrefix is the storage prefix of the chain profiles
+const ChainProfilesPrefix = "chains/"
+
+// DefaultGasLimit is the gas limit of Ethereum transactions when neither the
+// request nor the chain profile sets one, enough for a plain transfer
+const DefaultGasLimit = 21000
+
+// Chain families of chain profiles
+const (
+	FamilyEVM     = "evm"
+	FamilyBitcoin = "bitcoin"
+	FamilySolana  = "solana"
+	FamilyCosmos  = "cosmos"
+	FamilyTron    = "tron"
+	FamilyXRPL    = "xrpl"
+)
+
+// the chain accounts of each family are derived for
+var familyChains = map[string]string{
+	FamilyEVM:     ChainEthereum,
+	FamilyBitcoin: ChainBitcoin,
+	FamilySolana:  ChainSolana,
+	FamilyCosmos:  ChainCosmos,
+	FamilyTron:    ChainTron,
+	FamilyXRPL:    ChainXRPL,
+}
+
+// ExplorerHashPlaceholder is replaced by the transaction hash in explorer URL templates
+const ExplorerHashPlaceholder = "{hash}"
+
+// ChainProfile describes a network accounts are created for and sign on,
+// so requests name the chain instead of repeating its parameters.
+type ChainProfile struct {
+	Name   string `json:"name"`
+	Family string `json:"family"`
+
+	// ChainID is the EVM chain ID, the bitcoin network or the cosmos chain ID
+	ChainID  string `json:"chainID,omitempty"`
+	CoinType uint32 `json:"coinType"`
+
+	// DerivationPath overrides the default path of the family and coin type
+	DerivationPath string `json:"derivationPath,omitempty"`
+
+	// AddressFormat is the bitcoin address type or the cosmos bech32 prefix
+	AddressFormat string `json:"addressFormat,omitempty"`
+
+	DefaultGasLimit uint64 `json:"defaultGasLimit,omitempty"`
+
+	// FeeLimits are the default fee bounds of the chain ID, applied when no
+	// fee limits are configured for it
+	FeeLimits   *FeeLimits `json:"feeLimits,omitempty"`
+	ExplorerURL string     `json:"explorerURL,omitempty"`
+}
+
+// IsBuiltinChain reports whether the name is one of the chains accounts are
+// derived for, which chain profiles cannot be named after
+func IsBuiltinChain(name string) bool {
+	return containsFold(SupportedChains, name)
+}
+
+// ReadChainProfile returns the chain profile of the name, or nil if none is configured
+func ReadChainProfile(ctx context.Context, storage logical.Storage, name string) (*ChainProfile, error) {
+	entry, err := storage.Get(ctx, ChainProfilesPrefix+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var profile *ChainProfile
+	err = entry.DecodeJSON(&profile)
+	if err != nil {
+		return nil, errors.New("Fail to decode chain profile to JSON format")
+	}
+
+	return profile, nil
+}
+
+// FindChainFeeLimits returns the evm chain profile holding the default fee bounds
+// of the chain ID, or nil if none does. Only one profile of a chain ID may hold
+// fee bounds, other than the one of the name.
+func FindChainFeeLimits(ctx context.Context, storage logical.Storage, chainID string, name string) (*ChainProfile, error) {
+	names, err := storage.List(ctx, ChainProfilesPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range names {
+		if other == name {
+			continue
+		}
+		profile, err := ReadChainProfile(ctx, storage, other)
+		if err != nil {
+			return nil, err
+		}
+		if profile != nil && profile.Family == FamilyEVM && profile.ChainID == chainID && profile.FeeLimits != nil {
+			return profile, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Chain returns the chain accounts of the profile are derived for
+func (p *ChainProfile) Chain() string {
+	return familyChains[p.Family]
+}
+
+// Validate checks the profile is consistent with its family
+func (p *ChainProfile) Validate() error {
+	if IsBuiltinChain(p.Name) {
+		return fmt.Errorf("%s is a built-in chain and cannot name a chain profile", p.Name)
+	}
+	if _, ok := familyChains[p.Family]; !ok {
+		return fmt.Errorf("unsupported chain family %s", p.Family)
+	}
+	if p.CoinType >= 0x80000000 {
+		return fmt.Errorf("invalid coin_type %d", p.CoinType)
+	}
+
+	switch p.Family {
+	case FamilyEVM:
+		if _, err := p.EVMChainID(); err != nil {
+			return err
+		}
+	case FamilyBitcoin:
+		if _, err := BitcoinNetworkParams(p.ChainID); err != nil {
+			return err
+		}
+		if _, ok := bitcoinPurposes[p.AddressFormat]; !ok {
+			return fmt.Errorf("unsupported bitcoin address type %s", p.AddressFormat)
+		}
+	case FamilyCosmos:
+		if p.AddressFormat == "" {
+			return errors.New("the bech32 prefix of cosmos chains is required as address_format")
+		}
+	}
+	if p.AddressFormat != "" && p.Family != FamilyBitcoin && p.Family != FamilyCosmos {
+		return fmt.Errorf("%s chains have no address format", p.Family)
+	}
+
+	if p.Family != FamilyEVM && (p.DefaultGasLimit != 0 || p.FeeLimits != nil) {
+		return errors.New("gas and fee bounds only apply to evm chains")
+	}
+	if p.FeeLimits != nil {
+		if err := p.FeeLimits.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if p.ExplorerURL != "" && !strings.Contains(p.ExplorerURL, ExplorerHashPlaceholder) {
+		return fmt.Errorf("explorer_url must contain %s", ExplorerHashPlaceholder)
+	}
+
+	if p.DerivationPath != "" {
+		if _, err := ParseDerivationPath(p.DerivationPath); err != nil {
+			return fmt.Errorf("invalid derivation_path: %v", err)
+		}
+		if p.Family != FamilyEVM && !strings.HasPrefix(p.DerivationPath, "m") {
+			return fmt.Errorf("derivation_path of %s chains must be an absolute path", p.Family)
+		}
+	}
+
+	return nil
+}
+
+// EVMChainID returns the chain ID of an evm profile
+func (p *ChainProfile) EVMChainID() (*big.Int, error) {
+	chainID, ok := new(big.Int).SetString(p.ChainID, 10)
+	if !ok || chainID.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid chain ID %s of chain %s", p.ChainID, p.Name)
+	}
+	return chainID, nil
+}
+
+// DefaultDerivationPath returns the path of the first account of the profile.
+// Bitcoin paths use the purpose of the address type.
+func (p *ChainProfile) DefaultDerivationPath(addressType string) (string, error) {
+	if p.DerivationPath != "" {
+		return p.DerivationPath, nil
+	}
+
+	switch p.Family {
+	case FamilyBitcoin:
+		purpose, ok := bitcoinPurposes[addressType]
+		if !ok {
+			return "", fmt.Errorf("unsupported bitcoin address type %s", addressType)
+		}
+		return fmt.Sprintf("m/%d'/%d'/0'/0/0", purpose, p.CoinType), nil
+	case FamilySolana:
+		// ed25519 derivation only supports hardened indexes
+		return fmt.Sprintf("m/44'/%d'/0'/0'", p.CoinType), nil
+	}
+	return fmt.Sprintf("m/44'/%d'/0'/0/0", p.CoinType), nil
+}
+
+// ExplorerLink returns the explorer URL of the transaction, empty without template
+func (p *ChainProfile) ExplorerLink(hash string) string {
+	if p.ExplorerURL == "" || hash == "" {
+		return ""
+	}
+	return strings.Replace(p.ExplorerURL, ExplorerHashPlaceholder, hash, -1)
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func TestFindChainFeeLimits(t *testing.T) {
+	ctx := context.Background()
+	storage := &logical.InmemStorage{}
+
+	for _, profile := range []*ChainProfile{
+		{Name: "polygon", Family: FamilyEVM, ChainID: "137"},
+		{Name: "polygon-bounded", Family: FamilyEVM, ChainID: "137", FeeLimits: &FeeLimits{ChainID: "137", MaxGasPrice: "2000000000000"}},
+		{Name: "mainnet", Family: FamilyEVM, ChainID: "1"},
+	} {
+		entry, err := logical.StorageEntryJSON(ChainProfilesPrefix+profile.Name, profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = storage.Put(ctx, entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	profile, err := FindChainFeeLimits(ctx, storage, "137", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile == nil || profile.Name != "polygon-bounded" {
+		t.Fatalf("got profile %v, want polygon-bounded", profile)
+	}
+
+	// the profile being written is not its own other holder of the fee bounds
+	profile, err = FindChainFeeLimits(ctx, storage, "137", "polygon-bounded")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile != nil {
+		t.Errorf("got profile %s, want none", profile.Name)
+	}
+
+	profile, err = FindChainFeeLimits(ctx, storage, "1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile != nil {
+		t.Errorf("got profile %s for a chain ID without fee bounds", profile.Name)
+	}
+}
+
+func TestChainProfileFeeBoundsOnlyApplyToEVM(t *testing.T) {
+	profile := &ChainProfile{Name: "litecoin", Family: FamilyBitcoin, ChainID: NetworkMainnet, AddressFormat: AddressTypeP2WPKH, FeeLimits: &FeeLimits{MaxFee: "1"}}
+	if err := profile.Validate(); err == nil {
+		t.Error("a bitcoin profile with fee bounds was accepted")
+	}
+
+	profile = &ChainProfile{Name: "polygon", Family: FamilyEVM, ChainID: "137", FeeLimits: &FeeLimits{ChainID: "137", MaxGasPrice: "two"}}
+	if err := profile.Validate(); err == nil {
+		t.Error("an invalid max_gas_price was accepted")
+	}
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// ConfigPath is the storage path of the mount config
+const ConfigPath = "config"
+
+// Defaults of the mount config, matching the behaviour of mounts without config
+const (
+	DefaultEntropyBits = 256
+	// DefaultDerivationPrefix is the path relative derivation paths are appended to
+	DefaultDerivationPrefix = "m/44'/60'/0'/0"
+)
+
+// SupportedChains are the chains accounts can be derived for
+var SupportedChains = []string{ChainEthereum, ChainBitcoin, ChainSolana, ChainCosmos, ChainTron, ChainXRPL}
+
+// Config holds the mount-wide settings of the plugin
+type Config struct {
+	// EntropyBits is the entropy of generated mnemonics
+	EntropyBits int `json:"entropyBits"`
+
+	DefaultDerivationPrefix string `json:"defaultDerivationPrefix"`
+
+	// AllowedChains restricts the chains of accounts, empty allows every chain
+	AllowedChains []string `json:"allowedChains,omitempty"`
+
+	// ReturnMnemonic returns generated mnemonics and lets the wallet be read
+	ReturnMnemonic bool `json:"returnMnemonic"`
+
+	// RequireRole refuses signatures requested without a role
+	RequireRole bool `json:"requireRole,omitempty"`
+
+	// AllowFeeLimitOverride lets sign requests set override_fee_limits
+	AllowFeeLimitOverride bool `json:"allowFeeLimitOverride,omitempty"`
+}
+
+// DefaultConfig returns the config of mounts which have not been configured
+func DefaultConfig() *Config {
+	return &Config{
+		EntropyBits:             DefaultEntropyBits,
+		DefaultDerivationPrefix: DefaultDerivationPrefix,
+		ReturnMnemonic:          true,
+	}
+}
+
+// ReadConfig returns the mount config, or the default config if none is stored
+func ReadConfig(ctx context.Context, storage logical.Storage) (*Config, error) {
+	entry, err := storage.Get(ctx, ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return DefaultConfig(), nil
+	}
+
+	config := DefaultConfig()
+	err = entry.DecodeJSON(config)
+	if err != nil {
+		return nil, errors.New("Fail to decode config to JSON format")
+	}
+
+	return config, nil
+}
+
+// Validate checks every setting is well formed
+func (c *Config) Validate() error {
+	if c.EntropyBits < 128 || c.EntropyBits > 256 || c.EntropyBits%32 != 0 {
+		return fmt.Errorf("invalid entropy_bits %d, must be a multiple of 32 between 128 and 256", c.EntropyBits)
+	}
+
+	if !strings.HasPrefix(c.DefaultDerivationPrefix, "m") {
+		return fmt.Errorf("default_derivation_prefix %s is not an absolute path", c.DefaultDerivationPrefix)
+	}
+	if _, err := ParseDerivationPath(c.DefaultDerivationPrefix); err != nil {
+		return fmt.Errorf("invalid default_derivation_prefix: %v", err)
+	}
+
+	for _, chain := range c.AllowedChains {
+		if !containsFold(SupportedChains, chain) {
+			return fmt.Errorf("unsupported chain %s", chain)
+		}
+	}
+
+	return nil
+}
+
+// AllowsChain reports whether accounts of the chain may be created and used
+func (c *Config) AllowsChain(chain string) bool {
+	return len(c.AllowedChains) == 0 || containsFold(c.AllowedChains, chain)
+}
+
+// ResolveDerivationPath appends relative paths, such as "0" or "1/5", to the
+// default derivation prefix. The prefix is an ethereum path, so the accounts of
+// other chains need an absolute path.
+func (c *Config) ResolveDerivationPath(chain string, path string) (string, error) {
+	if path == "" || strings.HasPrefix(strings.TrimSpace(path), "m") {
+		return path, nil
+	}
+	if chain != ChainEthereum {
+		return "", fmt.Errorf("relative derivation path %s only applies to ethereum accounts, %s accounts need an absolute path", path, chain)
+	}
+	return strings.TrimSuffix(c.DefaultDerivationPrefix, "/") + "/" + strings.TrimPrefix(strings.TrimSpace(path), "/"), nil
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// ContractPrefix is the storage prefix of registered contracts
+const ContractPrefix = "contracts/"
+
+// Contract is a registered contract with its ABI
+type Contract struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	ChainID string `json:"chainID"`
+	ABI     string `json:"abi"`
+
+	// Decimals of an ERC-20 token contract, used to convert human units
+	Decimals *uint8 `json:"decimals,omitempty"`
+}
+
+// DecodedArgument is a single decoded calldata argument
+type DecodedArgument struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// DecodedCall is the human-readable form of a contract call
+type DecodedCall struct {
+	Contract  string             `json:"contract"`
+	Method    string             `json:"method"`
+	Signature string             `json:"signature"`
+	Arguments []*DecodedArgument `json:"arguments"`
+}
+
+// ReadContract returns the contract registered under name
+func ReadContract(ctx context.Context, storage logical.Storage, name string) (*Contract, error) {
+	entry, err := storage.Get(ctx, ContractPrefix+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var contract *Contract
+	err = entry.DecodeJSON(&contract)
+	if err != nil {
+		return nil, errors.New("Fail to decode contract to JSON format")
+	}
+
+	return contract, nil
+}
+
+// FindContract returns the contract registered at address on chainID
+func FindContract(ctx context.Context, storage logical.Storage, chainID *big.Int, address common.Address) (*Contract, error) {
+	names, err := storage.List(ctx, ContractPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		contract, err := ReadContract(ctx, storage, name)
+		if err != nil {
+			return nil, err
+		}
+		if contract == nil || contract.ChainID != chainID.String() {
+			continue
+		}
+		if common.HexToAddress(contract.Address) == address {
+			return contract, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ParseABI parses the JSON ABI of the contract
+func (c *Contract) ParseABI() (abi.ABI, error) {
+	return abi.JSON(strings.NewReader(c.ABI))
+}
+
+// DecodeCalldata decodes calldata against the ABI of the contract
+func (c *Contract) DecodeCalldata(calldata []byte) (*DecodedCall, error) {
+	contractABI, err := c.ParseABI()
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := contractABI.MethodById(calldata)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := method.Inputs.UnpackValues(calldata[4:])
+	if err != nil {
+		return nil, fmt.Errorf("calldata does not match %s: %v", method.Sig(), err)
+	}
+
+	arguments := make([]*DecodedArgument, len(values))
+	for i, value := range values {
+		arguments[i] = &DecodedArgument{
+			Name:  method.Inputs[i].Name,
+			Type:  method.Inputs[i].Type.String(),
+			Value: formatABIValue(reflect.ValueOf(value)),
+		}
+	}
+
+	return &DecodedCall{
+		Contract:  c.Name,
+		Method:    method.RawName,
+		Signature: method.Sig(),
+		Arguments: arguments,
+	}, nil
+}
+
+// formatABIValue renders decoded ABI values as JSON friendly strings
+func formatABIValue(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+
+	switch v := value.Interface().(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case bool, string:
+		return v
+	}
+
+	switch value.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf("%d", value.Int())
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("%d", value.Uint())
+	case reflect.Ptr:
+		return formatABIValue(value.Elem())
+	case reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			buf := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(buf), value)
+			return hexutil.Encode(buf)
+		}
+		fallthrough
+	case reflect.Slice:
+		items := make([]interface{}, value.Len())
+		for i := range items {
+			items[i] = formatABIValue(value.Index(i))
+		}
+		return items
+	case reflect.Struct:
+		fields := make(map[string]interface{}, value.NumField())
+		for i := 0; i < value.NumField(); i++ {
+			fields[value.Type().Field(i).Name] = formatABIValue(value.Field(i))
+		}
+		return fields
+	}
+
+	return fmt.Sprintf("%v", value.Interface())
+}
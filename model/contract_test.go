@@ -0,0 +1,82 @@
+package model
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// registered contracts are found by chain ID and address, whatever the address case
+func TestFindContract(t *testing.T) {
+	ctx := context.Background()
+	storage := &logical.InmemStorage{}
+
+	for _, contract := range []*Contract{
+		{Name: "usdc", Address: "0x2791bca1f2de4661ed88a30c99a7a9449aa84174", ChainID: "137", ABI: ERC20ABI},
+		{Name: "usdc-mainnet", Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", ChainID: "1", ABI: ERC20ABI},
+	} {
+		entry, err := logical.StorageEntryJSON(ContractPrefix+contract.Name, contract)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := storage.Put(ctx, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	contract, err := FindContract(ctx, storage, big.NewInt(137), common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contract == nil || contract.Name != "usdc" {
+		t.Fatalf("got contract %v, want usdc", contract)
+	}
+
+	contract, err = FindContract(ctx, storage, big.NewInt(1), common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contract != nil {
+		t.Fatalf("found contract %s on another chain", contract.Name)
+	}
+}
+
+func TestDecodeCalldata(t *testing.T) {
+	contract := &Contract{Name: "usdc", ABI: ERC20ABI}
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+	calldata, err := PackERC20Transfer(recipient, big.NewInt(5000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := contract.DecodeCalldata(calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Contract != "usdc" || decoded.Method != "transfer" || decoded.Signature != "transfer(address,uint256)" {
+		t.Fatalf("got %s %s %s", decoded.Contract, decoded.Method, decoded.Signature)
+	}
+	if len(decoded.Arguments) != 2 {
+		t.Fatalf("got %d arguments, want 2", len(decoded.Arguments))
+	}
+	if decoded.Arguments[0].Name != "to" || decoded.Arguments[0].Value != recipient.Hex() {
+		t.Errorf("got argument %s = %v", decoded.Arguments[0].Name, decoded.Arguments[0].Value)
+	}
+	if decoded.Arguments[1].Type != "uint256" || decoded.Arguments[1].Value != "5000000" {
+		t.Errorf("got argument %s %s = %v", decoded.Arguments[1].Type, decoded.Arguments[1].Name, decoded.Arguments[1].Value)
+	}
+
+	// an unknown selector and truncated arguments do not decode
+	for _, invalid := range [][]byte{
+		{0xde, 0xad, 0xbe, 0xef},
+		calldata[:20],
+	} {
+		if _, err := contract.DecodeCalldata(invalid); err == nil {
+			t.Errorf("calldata %x decoded", invalid)
+		}
+	}
+}
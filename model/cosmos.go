@@ -0,0 +1,165 @@
+package model
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/bech32"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ChainCosmos is the chain of Cosmos SDK accounts
+const ChainCosmos = "cosmos"
+
+// Defaults of Cosmos Hub accounts
+const (
+	DefaultCosmosPrefix   = "cosmos"
+	DefaultCosmosCoinType = 118
+)
+
+// CosmosSignDoc is the part of a sign document reviewed before signing
+type CosmosSignDoc struct {
+	ChainID       string `json:"chain_id"`
+	AccountNumber string `json:"account_number"`
+	Sequence      string `json:"sequence,omitempty"`
+}
+
+// CosmosDerivationPath returns the path of the first account of the coin type
+func CosmosDerivationPath(coinType uint32) string {
+	return fmt.Sprintf("m/44'/%d'/0'/0/0", coinType)
+}
+
+// CosmosAddress returns the bech32 address of the secp256k1 public key
+func CosmosAddress(publicKey *btcec.PublicKey, prefix string) (string, error) {
+	converted, err := bech32.ConvertBits(btcutil.Hash160(publicKey.SerializeCompressed()), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32.Encode(prefix, converted)
+}
+
+// DeriveCosmos derives a Cosmos SDK account with the bech32 prefix
+func (w *Wallet) DeriveCosmos(path accounts.DerivationPath, prefix string) (*Account, error) {
+	if prefix == "" {
+		return nil, errors.New("bech32 prefix is required")
+	}
+
+	key, err := w.deriveExtendedKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := CosmosAddress(privateKey.PubKey(), prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	URL := accounts.URL{
+		Scheme: "",
+		Path:   path.String(),
+	}
+
+	return &Account{
+		Address:    address,
+		URL:        URL.String(),
+		PrivateKey: hex.EncodeToString(privateKey.Serialize()),
+		PublicKey:  hex.EncodeToString(privateKey.PubKey().SerializeCompressed()),
+		Chain:      ChainCosmos,
+		Prefix:     prefix,
+	}, nil
+}
+
+// SignCosmos signs the SHA-256 of the sign bytes and returns the 64 bytes r || s
+// signature with a low s, as the Cosmos SDK secp256k1 keys do
+func SignCosmos(privateKey *btcec.PrivateKey, signBytes []byte) ([]byte, error) {
+	hash := sha256.Sum256(signBytes)
+	signature, err := privateKey.Sign(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return append(common.LeftPadBytes(signature.R.Bytes(), 32), common.LeftPadBytes(signature.S.Bytes(), 32)...), nil
+}
+
+// ParseCosmosDirectSignDoc reads the chain ID and account number of a protobuf
+// SIGN_MODE_DIRECT SignDoc, checking it only holds the fields of a SignDoc
+func ParseCosmosDirectSignDoc(raw []byte) (*CosmosSignDoc, error) {
+	doc := &CosmosSignDoc{AccountNumber: "0"}
+	r := bytes.NewReader(raw)
+	for r.Len() > 0 {
+		tag, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errors.New("invalid SignDoc")
+		}
+		field, wireType := tag>>3, tag&7
+
+		switch {
+		case (field == 1 || field == 2 || field == 3) && wireType == 2:
+			length, err := binary.ReadUvarint(r)
+			if err != nil || length > uint64(r.Len()) {
+				return nil, errors.New("invalid SignDoc")
+			}
+			value := make([]byte, length)
+			r.Read(value)
+			if field == 3 {
+				doc.ChainID = string(value)
+			}
+		case field == 4 && wireType == 0:
+			accountNumber, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, errors.New("invalid SignDoc")
+			}
+			doc.AccountNumber = strconv.FormatUint(accountNumber, 10)
+		default:
+			return nil, fmt.Errorf("unexpected SignDoc field %d", field)
+		}
+	}
+
+	if doc.ChainID == "" {
+		return nil, errors.New("SignDoc has no chain_id")
+	}
+	return doc, nil
+}
+
+// CanonicalAminoJSON returns the sign bytes of a SIGN_MODE_LEGACY_AMINO_JSON
+// StdSignDoc, its JSON with sorted keys and no whitespace
+func CanonicalAminoJSON(document string) ([]byte, *CosmosSignDoc, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.UseNumber()
+
+	var value map[string]interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, nil, errors.New("Fail to decode sign doc from JSON format")
+	}
+	if decoder.More() {
+		return nil, nil, errors.New("sign doc has trailing data")
+	}
+
+	signBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var doc CosmosSignDoc
+	if err := json.Unmarshal(signBytes, &doc); err != nil {
+		return nil, nil, errors.New("sign doc chain_id, account_number and sequence must be strings")
+	}
+	if doc.ChainID == "" {
+		return nil, nil, errors.New("sign doc has no chain_id")
+	}
+
+	return signBytes, &doc, nil
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// the address of the "abandon ... about" mnemonic is the one of Keplr and the
+// Cosmos SDK keyring, the second is the faucet account of the CosmJS tests
+func TestDeriveCosmos(t *testing.T) {
+	vectors := []struct {
+		mnemonic  string
+		address   string
+		publicKey string
+	}{
+		{testMnemonic, "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4", "024f4e2ad99c34d60b9ba6283c9431a8418af8673212961f97a77b6377fcd05b62"},
+		{
+			"economy stock theory fatal elder harbor betray wasp final emotion task crumble siren bottom lizard educate guess current outdoor pair theory focus wife stone",
+			"cosmos1pkptre7fdkl6gfrzlesjjvhxhlc3r4gmmk8rs6",
+			"034f04181eeba35391b858633a765c4a0c189697b40d216354d50890d350c70290",
+		},
+	}
+
+	for _, vector := range vectors {
+		wallet, err := NewWalletFromMnemonic(vector.mnemonic, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		account, err := wallet.DeriveCosmos(MustParseDerivationPath(CosmosDerivationPath(DefaultCosmosCoinType)), DefaultCosmosPrefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if account.Address != vector.address {
+			t.Errorf("got address %s, want %s", account.Address, vector.address)
+		}
+		if account.PublicKey != vector.publicKey {
+			t.Errorf("got public key %s, want %s", account.PublicKey, vector.publicKey)
+		}
+	}
+}
+
+func TestCanonicalAminoJSON(t *testing.T) {
+	document := `{
+		"sequence": "3",
+		"msgs": [{"value": {"to_address": "cosmos1b", "amount": [{"denom": "uatom", "amount": "1000"}], "from_address": "cosmos1a"}, "type": "cosmos-sdk/MsgSend"}],
+		"memo": "",
+		"fee": {"gas": "200000", "amount": [{"denom": "uatom", "amount": "5000"}]},
+		"chain_id": "cosmoshub-4",
+		"account_number": "12"
+	}`
+	want := `{"account_number":"12","chain_id":"cosmoshub-4","fee":{"amount":[{"amount":"5000","denom":"uatom"}],"gas":"200000"},"memo":"","msgs":[{"type":"cosmos-sdk/MsgSend","value":{"amount":[{"amount":"1000","denom":"uatom"}],"from_address":"cosmos1a","to_address":"cosmos1b"}}],"sequence":"3"}`
+
+	signBytes, doc, err := CanonicalAminoJSON(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(signBytes) != want {
+		t.Errorf("got sign bytes %s, want %s", signBytes, want)
+	}
+	if doc.ChainID != "cosmoshub-4" || doc.AccountNumber != "12" || doc.Sequence != "3" {
+		t.Errorf("got chain %s, account number %s and sequence %s", doc.ChainID, doc.AccountNumber, doc.Sequence)
+	}
+
+	for _, invalid := range []string{
+		`{"chain_id": "cosmoshub-4", "account_number": 12, "sequence": "3"}`,
+		`{"account_number": "12", "sequence": "3"}`,
+		`{"chain_id": "cosmoshub-4", "account_number": "12", "sequence": "3"} {}`,
+	} {
+		_, _, err := CanonicalAminoJSON(invalid)
+		if err == nil {
+			t.Errorf("sign doc %s was accepted", invalid)
+		}
+	}
+}
+
+func TestParseCosmosDirectSignDoc(t *testing.T) {
+	// body_bytes, auth_info_bytes, chain_id and account_number 300
+	raw := []byte{0x0a, 0x02, 0x01, 0x02, 0x12, 0x01, 0x03}
+	raw = append(raw, 0x1a, 0x0b)
+	raw = append(raw, "cosmoshub-4"...)
+	raw = append(raw, 0x20, 0xac, 0x02)
+
+	doc, err := ParseCosmosDirectSignDoc(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.ChainID != "cosmoshub-4" || doc.AccountNumber != "300" {
+		t.Errorf("got chain %s and account number %s", doc.ChainID, doc.AccountNumber)
+	}
+
+	invalids := map[string][]byte{
+		"unknown field":    append(append([]byte{}, raw...), 0x28, 0x01),
+		"truncated field":  raw[:len(raw)-4],
+		"missing chain_id": {0x0a, 0x02, 0x01, 0x02, 0x20, 0x01},
+	}
+	for name, invalid := range invalids {
+		_, err := ParseCosmosDirectSignDoc(invalid)
+		if err == nil {
+			t.Errorf("%s: the SignDoc was parsed", name)
+		}
+	}
+}
+
+func TestSignCosmos(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := wallet.DeriveCosmos(MustParseDerivationPath(CosmosDerivationPath(DefaultCosmosCoinType)), DefaultCosmosPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, publicKey := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, account.PrivateKey))
+
+	signBytes, _, err := CanonicalAminoJSON(`{"chain_id": "cosmoshub-4", "account_number": "12", "sequence": "3", "msgs": []}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := SignCosmos(privateKey, signBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signature) != 64 {
+		t.Fatalf("got a signature of %d bytes, want 64", len(signature))
+	}
+
+	parsed := &btcec.Signature{R: new(big.Int).SetBytes(signature[:32]), S: new(big.Int).SetBytes(signature[32:])}
+	if parsed.S.Cmp(new(big.Int).Rsh(btcec.S256().N, 1)) > 0 {
+		t.Error("the signature has a high s")
+	}
+	hash := sha256.Sum256(signBytes)
+	if !parsed.Verify(hash[:], publicKey) {
+		t.Error("the signature does not verify")
+	}
+	tampered := sha256.Sum256(append(signBytes, ' '))
+	if parsed.Verify(tampered[:], publicKey) {
+		t.Error("the signature verifies other sign bytes")
+	}
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ERC20ABI is the subset of the ERC-20 interface the plugin builds calldata for
+const ERC20ABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"transferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+
+var erc20ABI abi.ABI
+
+func init() {
+	var err error
+	erc20ABI, err = abi.JSON(strings.NewReader(ERC20ABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// PackERC20Transfer returns the calldata of transfer(address,uint256)
+func PackERC20Transfer(to common.Address, value *big.Int) ([]byte, error) {
+	return erc20ABI.Pack("transfer", to, value)
+}
+
+// PackERC20Approve returns the calldata of approve(address,uint256)
+func PackERC20Approve(spender common.Address, value *big.Int) ([]byte, error) {
+	return erc20ABI.Pack("approve", spender, value)
+}
+
+// DecodeERC20Call returns the beneficiary and amount of transfer, transferFrom or
+// approve calldata, the spender being the beneficiary of an approval
+func DecodeERC20Call(calldata []byte) (common.Address, *big.Int, bool) {
+	if len(calldata) < 4 {
+		return common.Address{}, nil, false
+	}
+	method, err := erc20ABI.MethodById(calldata[:4])
+	if err != nil {
+		return common.Address{}, nil, false
+	}
+
+	args := map[string]interface{}{}
+	if err := method.Inputs.UnpackIntoMap(args, calldata[4:]); err != nil {
+		return common.Address{}, nil, false
+	}
+
+	beneficiary, ok := args["to"].(common.Address)
+	if method.Name == "approve" {
+		beneficiary, ok = args["spender"].(common.Address)
+	}
+	value, isValue := args["value"].(*big.Int)
+	if !ok || !isValue {
+		return common.Address{}, nil, false
+	}
+
+	return beneficiary, value, true
+}
+
+// CallTransfer returns the beneficiary and amount of a call sending the value with
+// the calldata to the address. They are those of the ERC-20 call when the calldata
+// is one and no native value is sent, token reporting the address is a token.
+func CallTransfer(to common.Address, value *big.Int, calldata []byte) (beneficiary common.Address, amount *big.Int, token bool) {
+	if value == nil || value.Sign() == 0 {
+		if beneficiary, amount, ok := DecodeERC20Call(calldata); ok {
+			return beneficiary, amount, true
+		}
+	}
+	if value == nil {
+		value = new(big.Int)
+	}
+	return to, value, false
+}
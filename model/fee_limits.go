@@ -0,0 +1,126 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// FeeLimitsPrefix is the storage prefix of the per-chain fee limits
+const FeeLimitsPrefix = "fee-limits/"
+
+// DefaultMaxGasPrice is the gas price bound, 10,000 gwei, of chains whose
+// fee limits do not set one, so that no chain signs any gas price
+const DefaultMaxGasPrice = "10000000000000"
+
+// FeeLimits are the sanity bounds of the fees signed on a chain.
+// Amounts are in wei, empty values are not checked but for the maximum gas
+// price, which defaults to DefaultMaxGasPrice.
+type FeeLimits struct {
+	ChainID            string `json:"chainID"`
+	MinGasPrice        string `json:"minGasPrice,omitempty"`
+	MaxGasPrice        string `json:"maxGasPrice,omitempty"`
+	MaxPriorityFee     string `json:"maxPriorityFee,omitempty"`
+	MaxFee             string `json:"maxFee,omitempty"`
+	MaxFeeToValueRatio string `json:"maxFeeToValueRatio,omitempty"`
+}
+
+// ReadFeeLimits returns the fee limits of the chain, or nil if none are configured
+func ReadFeeLimits(ctx context.Context, storage logical.Storage, chainID *big.Int) (*FeeLimits, error) {
+	entry, err := storage.Get(ctx, FeeLimitsPrefix+chainID.String())
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var limits *FeeLimits
+	err = entry.DecodeJSON(&limits)
+	if err != nil {
+		return nil, errors.New("Fail to decode fee limits to JSON format")
+	}
+
+	return limits, nil
+}
+
+// Validate checks that every configured bound is well formed
+func (l *FeeLimits) Validate() error {
+	for name, value := range map[string]string{
+		"min_gas_price":    l.MinGasPrice,
+		"max_gas_price":    l.MaxGasPrice,
+		"max_priority_fee": l.MaxPriorityFee,
+		"max_fee":          l.MaxFee,
+	} {
+		if _, err := parseWei(value); err != nil {
+			return fmt.Errorf("invalid %s: %v", name, err)
+		}
+	}
+
+	if l.MaxFeeToValueRatio != "" {
+		ratio, ok := new(big.Rat).SetString(l.MaxFeeToValueRatio)
+		if !ok || ratio.Sign() < 0 {
+			return fmt.Errorf("invalid max_fee_to_value_ratio %s", l.MaxFeeToValueRatio)
+		}
+	}
+
+	return nil
+}
+
+// Check returns an error describing the first bound the fee violates.
+// priorityFee is nil for legacy transactions, which may tip up to their
+// whole gas price, so the gas price is checked against the priority fee bound.
+func (l *FeeLimits) Check(gasLimit uint64, gasPrice *big.Int, priorityFee *big.Int, value *big.Int) error {
+	if minGasPrice, _ := parseWei(l.MinGasPrice); minGasPrice != nil && gasPrice.Cmp(minGasPrice) < 0 {
+		return fmt.Errorf("gas price %s is below the minimum of %s wei", gasPrice, minGasPrice)
+	}
+
+	maxGasPrice, _ := parseWei(l.MaxGasPrice)
+	if maxGasPrice == nil {
+		maxGasPrice, _ = parseWei(DefaultMaxGasPrice)
+	}
+	if gasPrice.Cmp(maxGasPrice) > 0 {
+		return fmt.Errorf("gas price %s exceeds the maximum of %s wei", gasPrice, maxGasPrice)
+	}
+
+	if priorityFee == nil {
+		priorityFee = gasPrice
+	}
+	if maxPriorityFee, _ := parseWei(l.MaxPriorityFee); maxPriorityFee != nil && priorityFee.Cmp(maxPriorityFee) > 0 {
+		return fmt.Errorf("priority fee %s exceeds the maximum of %s wei", priorityFee, maxPriorityFee)
+	}
+
+	fee := MaxTransactionFee(gasLimit, gasPrice)
+	if maxFee, _ := parseWei(l.MaxFee); maxFee != nil && fee.Cmp(maxFee) > 0 {
+		return fmt.Errorf("total fee %s exceeds the maximum of %s wei", fee, maxFee)
+	}
+
+	if l.MaxFeeToValueRatio != "" && value != nil && value.Sign() > 0 {
+		maxRatio, _ := new(big.Rat).SetString(l.MaxFeeToValueRatio)
+		ratio := new(big.Rat).SetFrac(fee, value)
+		if ratio.Cmp(maxRatio) > 0 {
+			return fmt.Errorf("total fee is %s of the value, exceeding the maximum ratio of %s", ratio.FloatString(4), l.MaxFeeToValueRatio)
+		}
+	}
+
+	return nil
+}
+
+// MaxTransactionFee returns the most a transaction can pay for gas
+func MaxTransactionFee(gasLimit uint64, gasPrice *big.Int) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice)
+}
+
+func parseWei(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("%s is not a non-negative integer", value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,363 @@
+package model
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// HistoryPrefix is the storage prefix of the signing history
+const HistoryPrefix = "history/"
+
+// HistoryKeyPath is the storage path of the secret key the history entries are hashed with
+const HistoryKeyPath = "history-key"
+
+// historyKeyLock serializes the generation of the history key
+var historyKeyLock sync.Mutex
+
+// Signature types recorded in the signing history
+const (
+	SignatureTypeData            = "sign"
+	SignatureTypeTransaction     = "sign-tx"
+	SignatureTypeSIWE            = "sign-siwe"
+	SignatureTypeSafeTransaction = "sign-safe-tx"
+	SignatureTypeUserOperation   = "sign-user-operation"
+	SignatureTypePSBT            = "sign-psbt"
+	SignatureTypeBitcoinMessage  = "sign-message"
+	SignatureTypeSolana          = "sign-solana-tx"
+	SignatureTypeCosmos          = "sign-cosmos"
+	SignatureTypeTron            = "sign-tron-tx"
+	SignatureTypeXRPL            = "sign-xrpl-tx"
+)
+
+// HistoryEntry records a single signature produced by an account.
+// To and Value are the beneficiary and amount the signature authorizes,
+// those of the token transfer or of the call a smart account makes when
+// Contract is the token or account called.
+// Every entry carries the hash of the previous one so that any change
+// to the history breaks the chain, and the hashes are keyed with a secret
+// so that storage access alone cannot rebuild the chain.
+type HistoryEntry struct {
+	Sequence  uint64    `json:"sequence"`
+	Time      time.Time `json:"time"`
+	EntityID  string    `json:"entityID"`
+	Type      string    `json:"type"`
+	ChainID   string    `json:"chainID,omitempty"`
+	To        string    `json:"to,omitempty"`
+	Value     string    `json:"value,omitempty"`
+	Contract  string    `json:"contract,omitempty"`
+	Digest    string    `json:"digest"`
+	TxHash    string    `json:"txHash,omitempty"`
+	PrevHash  string    `json:"prevHash"`
+	EntryHash string    `json:"entryHash"`
+}
+
+// historyHead points at the latest entry of an account history. It is keyed
+// with the history key too, so that removing the latest entries and pointing
+// the head at an earlier one is detected.
+type historyHead struct {
+	Sequence  uint64 `json:"sequence"`
+	EntryHash string `json:"entryHash"`
+	MAC       string `json:"mac"`
+}
+
+// HistoryVerification is the result of verifying an account history
+type HistoryVerification struct {
+	Valid    bool   `json:"valid"`
+	Entries  uint64 `json:"entries"`
+	HeadHash string `json:"headHash"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ComputeHash returns the HMAC-SHA256 of the entry under the history key,
+// covering every field but EntryHash
+func (e *HistoryEntry) ComputeHash(key []byte) (string, error) {
+	content := *e
+	content.EntryHash = ""
+	content.Time = content.Time.UTC()
+
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(encoded)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// readHistoryKey returns the history key, generating it on first use if create is set.
+// It returns nil if the key was never generated. Generating the key starts the history
+// of every existing account, so that every account has a head from then on.
+func readHistoryKey(ctx context.Context, storage logical.Storage, create bool) ([]byte, error) {
+	historyKeyLock.Lock()
+	defer historyKeyLock.Unlock()
+
+	entry, err := storage.Get(ctx, HistoryKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		return entry.Value, nil
+	}
+	if !create {
+		return nil, nil
+	}
+
+	key := make([]byte, 32)
+	_, err = rand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	names, err := storage.List(ctx, "accounts/")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		err = putHistoryHead(ctx, storage, key, name, &historyHead{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = storage.Put(ctx, &logical.StorageEntry{
+		Key:   HistoryKeyPath,
+		Value: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func historyEntryKey(name string, sequence uint64) string {
+	return fmt.Sprintf("%s%s/entries/%020d", HistoryPrefix, name, sequence)
+}
+
+func historyHeadKey(name string) string {
+	return HistoryPrefix + name + "/head"
+}
+
+// readHistoryHead returns the head of the account history, or nil if the history was never started
+func readHistoryHead(ctx context.Context, storage logical.Storage, name string) (*historyHead, error) {
+	entry, err := storage.Get(ctx, historyHeadKey(name))
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var head *historyHead
+	err = entry.DecodeJSON(&head)
+	if err != nil {
+		return nil, errors.New("Fail to decode history head to JSON format")
+	}
+
+	return head, nil
+}
+
+func putHistoryHead(ctx context.Context, storage logical.Storage, key []byte, name string, head *historyHead) error {
+	head.MAC = head.computeMAC(key, name)
+	entry, err := logical.StorageEntryJSON(historyHeadKey(name), head)
+	if err != nil {
+		return err
+	}
+
+	return storage.Put(ctx, entry)
+}
+
+// computeMAC returns the HMAC-SHA256 of the head of the account under the history key
+func (h *historyHead) computeMAC(key []byte, name string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(fmt.Sprintf("%s\x00%d\x00%s", name, h.Sequence, h.EntryHash)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// authentic reports whether the head of the account was written with the history key
+func (h *historyHead) authentic(key []byte, name string) bool {
+	return hmac.Equal([]byte(h.computeMAC(key, name)), []byte(h.MAC))
+}
+
+// StartHistory starts the empty history of a new account, so that removing
+// the whole history later is detected as a missing head
+func StartHistory(ctx context.Context, storage logical.Storage, name string) error {
+	key, err := readHistoryKey(ctx, storage, true)
+	if err != nil {
+		return err
+	}
+
+	head, err := readHistoryHead(ctx, storage, name)
+	if err != nil || head != nil {
+		return err
+	}
+
+	return putHistoryHead(ctx, storage, key, name, &historyHead{})
+}
+
+// AppendHistory chains the entry to the history of the account and saves it.
+// Callers must serialize appends of the same account.
+func AppendHistory(ctx context.Context, storage logical.Storage, name string, historyEntry *HistoryEntry) error {
+	key, err := readHistoryKey(ctx, storage, true)
+	if err != nil {
+		return err
+	}
+
+	head, err := readHistoryHead(ctx, storage, name)
+	if err != nil {
+		return err
+	}
+	if head == nil {
+		return fmt.Errorf("history head of account %s is missing", name)
+	}
+	if !head.authentic(key, name) {
+		return fmt.Errorf("history head of account %s has been modified", name)
+	}
+
+	historyEntry.Sequence = head.Sequence + 1
+	historyEntry.PrevHash = head.EntryHash
+	historyEntry.Time = historyEntry.Time.UTC()
+	historyEntry.EntryHash, err = historyEntry.ComputeHash(key)
+	if err != nil {
+		return err
+	}
+
+	entry, err := logical.StorageEntryJSON(historyEntryKey(name, historyEntry.Sequence), historyEntry)
+	if err != nil {
+		return err
+	}
+	err = storage.Put(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	return putHistoryHead(ctx, storage, key, name, &historyHead{
+		Sequence:  historyEntry.Sequence,
+		EntryHash: historyEntry.EntryHash,
+	})
+}
+
+// ReadHistoryEntry returns a single entry of the account history
+func ReadHistoryEntry(ctx context.Context, storage logical.Storage, name string, sequence uint64) (*HistoryEntry, error) {
+	entry, err := storage.Get(ctx, historyEntryKey(name, sequence))
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var historyEntry *HistoryEntry
+	err = entry.DecodeJSON(&historyEntry)
+	if err != nil {
+		return nil, errors.New("Fail to decode history entry to JSON format")
+	}
+
+	return historyEntry, nil
+}
+
+// ReadHistory returns every entry of the account history in order
+func ReadHistory(ctx context.Context, storage logical.Storage, name string) ([]*HistoryEntry, error) {
+	keys, err := storage.List(ctx, HistoryPrefix+name+"/entries/")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*HistoryEntry, 0, len(keys))
+	for _, key := range keys {
+		sequence, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected history key %s", key)
+		}
+
+		historyEntry, err := ReadHistoryEntry(ctx, storage, name, sequence)
+		if err != nil {
+			return nil, err
+		}
+		if historyEntry != nil {
+			entries = append(entries, historyEntry)
+		}
+	}
+
+	return entries, nil
+}
+
+// VerifyHistory checks the hash chain of the account history against its authenticated head
+func VerifyHistory(ctx context.Context, storage logical.Storage, name string) (*HistoryVerification, error) {
+	head, err := readHistoryHead(ctx, storage, name)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ReadHistory(ctx, storage, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HistoryVerification{
+		Entries: uint64(len(entries)),
+	}
+
+	key, err := readHistoryKey(ctx, storage, false)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		// no account was created nor signed since the history key exists
+		if len(entries) > 0 || head != nil {
+			result.Error = "history key is missing"
+			return result, nil
+		}
+		result.Valid = true
+		return result, nil
+	}
+	if head == nil {
+		result.Error = "history head is missing"
+		return result, nil
+	}
+	if !head.authentic(key, name) {
+		result.Error = "history head has been modified"
+		return result, nil
+	}
+	result.HeadHash = head.EntryHash
+
+	prevHash := ""
+	for i, historyEntry := range entries {
+		if historyEntry.Sequence != uint64(i+1) {
+			result.Error = fmt.Sprintf("entry %d is missing", i+1)
+			return result, nil
+		}
+		if historyEntry.PrevHash != prevHash {
+			result.Error = fmt.Sprintf("entry %d does not chain to entry %d", historyEntry.Sequence, i)
+			return result, nil
+		}
+		hash, err := historyEntry.ComputeHash(key)
+		if err != nil {
+			return nil, err
+		}
+		if !hmac.Equal([]byte(hash), []byte(historyEntry.EntryHash)) {
+			result.Error = fmt.Sprintf("entry %d has been modified", historyEntry.Sequence)
+			return result, nil
+		}
+		prevHash = hash
+	}
+
+	if head.Sequence != result.Entries || head.EntryHash != prevHash {
+		result.Error = "history head does not match the last entry"
+		return result, nil
+	}
+
+	result.Valid = true
+	return result, nil
+}
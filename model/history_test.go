@@ -0,0 +1,112 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// newTestHistory returns a storage holding the history of 3 signatures of the account
+func newTestHistory(t *testing.T, name string) logical.Storage {
+	ctx := context.Background()
+	storage := &logical.InmemStorage{}
+
+	err := StartHistory(ctx, storage, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []string{"1", "2", "3"} {
+		err = AppendHistory(ctx, storage, name, &HistoryEntry{
+			Type:    SignatureTypeTransaction,
+			ChainID: "1",
+			Value:   value,
+			Digest:  "0x" + value,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return storage
+}
+
+func TestVerifyHistory(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestHistory(t, "treasury")
+
+	result, err := VerifyHistory(ctx, storage, "treasury")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || result.Entries != 3 {
+		t.Fatalf("got valid %v with %d entries: %s", result.Valid, result.Entries, result.Error)
+	}
+}
+
+func TestVerifyHistoryDetectsTampering(t *testing.T) {
+	ctx := context.Background()
+
+	tamperings := map[string]func(storage logical.Storage) error{
+		"modified entry": func(storage logical.Storage) error {
+			entry, err := ReadHistoryEntry(ctx, storage, "treasury", 2)
+			if err != nil {
+				return err
+			}
+			entry.Value = "1000"
+			stored, err := logical.StorageEntryJSON(historyEntryKey("treasury", 2), entry)
+			if err != nil {
+				return err
+			}
+			return storage.Put(ctx, stored)
+		},
+		"truncated history": func(storage logical.Storage) error {
+			previous, err := ReadHistoryEntry(ctx, storage, "treasury", 2)
+			if err != nil {
+				return err
+			}
+			err = storage.Delete(ctx, historyEntryKey("treasury", 3))
+			if err != nil {
+				return err
+			}
+			stored, err := logical.StorageEntryJSON(historyHeadKey("treasury"), &historyHead{
+				Sequence:  previous.Sequence,
+				EntryHash: previous.EntryHash,
+			})
+			if err != nil {
+				return err
+			}
+			return storage.Put(ctx, stored)
+		},
+		"removed history": func(storage logical.Storage) error {
+			for _, key := range []string{historyEntryKey("treasury", 1), historyEntryKey("treasury", 2), historyEntryKey("treasury", 3), historyHeadKey("treasury")} {
+				err := storage.Delete(ctx, key)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	for name, tamper := range tamperings {
+		storage := newTestHistory(t, "treasury")
+		err := tamper(storage)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := VerifyHistory(ctx, storage, "treasury")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Valid {
+			t.Errorf("%s: the history verifies", name)
+		}
+
+		err = AppendHistory(ctx, storage, "treasury", &HistoryEntry{Type: SignatureTypeData, Digest: "0x04"})
+		if name != "modified entry" && err == nil {
+			t.Errorf("%s: a signature was appended to the history", name)
+		}
+	}
+}
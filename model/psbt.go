@@ -0,0 +1,838 @@
+package model
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// psbtMagic prefixes every BIP-174 PSBT
+var psbtMagic = []byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+// Key types of the BIP-174 and BIP-371 PSBT maps used by the signer
+const (
+	PSBTGlobalUnsignedTx = 0x00
+	PSBTGlobalVersion    = 0xfb
+
+	PSBTInNonWitnessUTXO     = 0x00
+	PSBTInWitnessUTXO        = 0x01
+	PSBTInPartialSig         = 0x02
+	PSBTInSighashType        = 0x03
+	PSBTInRedeemScript       = 0x04
+	PSBTInWitnessScript      = 0x05
+	PSBTInBIP32Derivation    = 0x06
+	PSBTInFinalScriptSig     = 0x07
+	PSBTInFinalScriptWitness = 0x08
+	PSBTInTapKeySig          = 0x13
+	PSBTInTapBIP32Derivation = 0x16
+	PSBTInTapInternalKey     = 0x17
+	PSBTInTapMerkleRoot      = 0x18
+
+	PSBTOutBIP32Derivation    = 0x02
+	PSBTOutTapBIP32Derivation = 0x07
+)
+
+// Bitcoin output script types
+const (
+	ScriptTypeP2PKH   = "p2pkh"
+	ScriptTypeP2SH    = "p2sh"
+	ScriptTypeP2WPKH  = "p2wpkh"
+	ScriptTypeP2WSH   = "p2wsh"
+	ScriptTypeP2TR    = "p2tr"
+	ScriptTypeUnknown = "unknown"
+)
+
+// sigHashDefault is the BIP-341 taproot sighash type committing like SIGHASH_ALL
+const sigHashDefault = 0x00
+
+// PSBTPair is a key-value pair of a PSBT map, the key starting with its type
+type PSBTPair struct {
+	Key   []byte
+	Value []byte
+}
+
+// PSBTMap is a PSBT map keeping the pairs in their original order, so that
+// fields the signer does not know about survive a round trip
+type PSBTMap []*PSBTPair
+
+// Get returns the value of the key, nil if absent
+func (m PSBTMap) Get(key ...byte) []byte {
+	for _, pair := range m {
+		if bytes.Equal(pair.Key, key) {
+			return pair.Value
+		}
+	}
+	return nil
+}
+
+// All returns the pairs of the key type
+func (m PSBTMap) All(keyType byte) []*PSBTPair {
+	pairs := []*PSBTPair{}
+	for _, pair := range m {
+		if pair.Key[0] == keyType {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
+
+// Set adds the pair, replacing the value of an existing key
+func (m *PSBTMap) Set(key []byte, value []byte) {
+	for _, pair := range *m {
+		if bytes.Equal(pair.Key, key) {
+			pair.Value = value
+			return
+		}
+	}
+	*m = append(*m, &PSBTPair{Key: key, Value: value})
+}
+
+// PSBT is a version 0 BIP-174 partially signed bitcoin transaction
+type PSBT struct {
+	UnsignedTx *wire.MsgTx
+	Global     PSBTMap
+	Inputs     []PSBTMap
+	Outputs    []PSBTMap
+}
+
+// PSBTPrevout is the output spent by a PSBT input
+type PSBTPrevout struct {
+	Amount   int64
+	PkScript []byte
+
+	// Verified reports whether the output has been read from the previous
+	// transaction, whose hash is committed to by the outpoint of the input
+	Verified bool
+}
+
+// PSBTDerivation is the BIP-32 derivation of a key of a PSBT input or output
+type PSBTDerivation struct {
+	// PublicKey is the compressed public key, or the x-only public key of a taproot derivation
+	PublicKey []byte
+	Path      accounts.DerivationPath
+	Taproot   bool
+}
+
+// DecodePSBT decodes a base64 PSBT
+func DecodePSBT(encoded string) (*PSBT, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("Fail to decode PSBT from base64 format")
+	}
+	if !bytes.HasPrefix(raw, psbtMagic) {
+		return nil, errors.New("invalid PSBT magic")
+	}
+	r := bytes.NewReader(raw[len(psbtMagic):])
+
+	global, err := readPSBTMap(r)
+	if err != nil {
+		return nil, err
+	}
+
+	packet := &PSBT{}
+	for _, pair := range global {
+		switch {
+		case len(pair.Key) == 1 && pair.Key[0] == PSBTGlobalUnsignedTx:
+			tx := wire.NewMsgTx(wire.TxVersion)
+			if err := tx.DeserializeNoWitness(bytes.NewReader(pair.Value)); err != nil {
+				return nil, fmt.Errorf("invalid PSBT unsigned transaction: %v", err)
+			}
+			for _, txIn := range tx.TxIn {
+				if len(txIn.SignatureScript) > 0 || len(txIn.Witness) > 0 {
+					return nil, errors.New("PSBT unsigned transaction has input scripts")
+				}
+			}
+			packet.UnsignedTx = tx
+		case len(pair.Key) == 1 && pair.Key[0] == PSBTGlobalVersion:
+			if len(pair.Value) != 4 || binary.LittleEndian.Uint32(pair.Value) != 0 {
+				return nil, errors.New("only PSBT version 0 is supported")
+			}
+			packet.Global = append(packet.Global, pair)
+		default:
+			packet.Global = append(packet.Global, pair)
+		}
+	}
+	if packet.UnsignedTx == nil {
+		return nil, errors.New("PSBT has no unsigned transaction")
+	}
+
+	for range packet.UnsignedTx.TxIn {
+		input, err := readPSBTMap(r)
+		if err != nil {
+			return nil, err
+		}
+		packet.Inputs = append(packet.Inputs, input)
+	}
+	for range packet.UnsignedTx.TxOut {
+		output, err := readPSBTMap(r)
+		if err != nil {
+			return nil, err
+		}
+		packet.Outputs = append(packet.Outputs, output)
+	}
+	if r.Len() != 0 {
+		return nil, errors.New("PSBT has trailing data")
+	}
+
+	return packet, nil
+}
+
+// Encode returns the base64 encoding of the PSBT
+func (p *PSBT) Encode() (string, error) {
+	var buf bytes.Buffer
+	buf.Write(psbtMagic)
+
+	var unsignedTx bytes.Buffer
+	if err := p.UnsignedTx.SerializeNoWitness(&unsignedTx); err != nil {
+		return "", err
+	}
+	global := append(PSBTMap{{Key: []byte{PSBTGlobalUnsignedTx}, Value: unsignedTx.Bytes()}}, p.Global...)
+
+	maps := append([]PSBTMap{global}, p.Inputs...)
+	maps = append(maps, p.Outputs...)
+	for _, m := range maps {
+		for _, pair := range m {
+			if err := wire.WriteVarBytes(&buf, 0, pair.Key); err != nil {
+				return "", err
+			}
+			if err := wire.WriteVarBytes(&buf, 0, pair.Value); err != nil {
+				return "", err
+			}
+		}
+		buf.WriteByte(0x00)
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func readPSBTMap(r *bytes.Reader) (PSBTMap, error) {
+	m := PSBTMap{}
+	seen := map[string]bool{}
+	for {
+		key, err := wire.ReadVarBytes(r, 0, uint32(r.Len()), "PSBT key")
+		if err == io.EOF {
+			return nil, errors.New("PSBT map is not terminated")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(key) == 0 {
+			return m, nil
+		}
+		if seen[string(key)] {
+			return nil, fmt.Errorf("duplicated PSBT key %x", key)
+		}
+		seen[string(key)] = true
+
+		value, err := wire.ReadVarBytes(r, 0, uint32(r.Len()), "PSBT value")
+		if err != nil {
+			return nil, err
+		}
+		m = append(m, &PSBTPair{Key: key, Value: value})
+	}
+}
+
+// Prevout returns the output spent by the input, nil if the PSBT does not carry it.
+// The output of the non-witness utxo is preferred as its amount is verified
+// against the outpoint, a witness utxo being only trusted when there is none.
+func (p *PSBT) Prevout(index int) (*PSBTPrevout, error) {
+	input := p.Inputs[index]
+
+	var prevout *PSBTPrevout
+	if value := input.Get(PSBTInNonWitnessUTXO); value != nil {
+		prevTx := wire.NewMsgTx(wire.TxVersion)
+		if err := prevTx.Deserialize(bytes.NewReader(value)); err != nil {
+			return nil, fmt.Errorf("invalid non-witness utxo of input %d: %v", index, err)
+		}
+		outPoint := p.UnsignedTx.TxIn[index].PreviousOutPoint
+		if prevTx.TxHash() != outPoint.Hash {
+			return nil, fmt.Errorf("non-witness utxo of input %d does not match its outpoint", index)
+		}
+		if int(outPoint.Index) >= len(prevTx.TxOut) {
+			return nil, fmt.Errorf("outpoint of input %d is out of range", index)
+		}
+		txOut := prevTx.TxOut[outPoint.Index]
+		prevout = &PSBTPrevout{Amount: txOut.Value, PkScript: txOut.PkScript, Verified: true}
+	}
+
+	if value := input.Get(PSBTInWitnessUTXO); value != nil {
+		txOut, err := readTxOut(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid witness utxo of input %d: %v", index, err)
+		}
+		if prevout == nil {
+			prevout = &PSBTPrevout{Amount: txOut.Value, PkScript: txOut.PkScript}
+		} else if txOut.Value != prevout.Amount || !bytes.Equal(txOut.PkScript, prevout.PkScript) {
+			return nil, fmt.Errorf("witness utxo of input %d does not match its non-witness utxo", index)
+		}
+	}
+
+	if prevout != nil && (prevout.Amount < 0 || prevout.Amount > btcutil.MaxSatoshi) {
+		return nil, fmt.Errorf("amount %d of the utxo of input %d is out of range", prevout.Amount, index)
+	}
+	return prevout, nil
+}
+
+// SighashType returns the sighash type requested by the input, or the default of its script type
+func (p *PSBT) SighashType(index int, taproot bool) (uint32, error) {
+	value := p.Inputs[index].Get(PSBTInSighashType)
+	if value == nil {
+		if taproot {
+			return sigHashDefault, nil
+		}
+		return uint32(txscript.SigHashAll), nil
+	}
+	if len(value) != 4 {
+		return 0, fmt.Errorf("invalid sighash type of input %d", index)
+	}
+	return binary.LittleEndian.Uint32(value), nil
+}
+
+func (p *PSBT) signingSighashType(index int, taproot bool, allowAnySighash bool) (uint32, error) {
+	hashType, err := p.SighashType(index, taproot)
+	if err != nil {
+		return 0, err
+	}
+	if !allowAnySighash && hashType != uint32(txscript.SigHashAll) && hashType != sigHashDefault {
+		return 0, fmt.Errorf("input %d requests sighash type %d", index, hashType)
+	}
+	return hashType, nil
+}
+
+// HasDerivation reports whether a BIP-32 or taproot BIP-32 derivation of the input is for the public key
+func (p *PSBT) HasDerivation(index int, publicKey *btcec.PublicKey) bool {
+	input := p.Inputs[index]
+	if input.Get(append([]byte{PSBTInBIP32Derivation}, publicKey.SerializeCompressed()...)...) != nil {
+		return true
+	}
+	return input.Get(append([]byte{PSBTInTapBIP32Derivation}, XOnlyPublicKey(publicKey)...)...) != nil
+}
+
+// InputDerivations returns the BIP-32 and taproot BIP-32 derivations of the
+// input keys from the master key of the fingerprint
+func (p *PSBT) InputDerivations(index int, fingerprint []byte) ([]*PSBTDerivation, error) {
+	derivations, err := readDerivations(p.Inputs[index], PSBTInBIP32Derivation, PSBTInTapBIP32Derivation, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation of input %d: %v", index, err)
+	}
+	return derivations, nil
+}
+
+// OutputDerivations returns the BIP-32 and taproot BIP-32 derivations of the
+// output keys from the master key of the fingerprint
+func (p *PSBT) OutputDerivations(index int, fingerprint []byte) ([]*PSBTDerivation, error) {
+	derivations, err := readDerivations(p.Outputs[index], PSBTOutBIP32Derivation, PSBTOutTapBIP32Derivation, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation of output %d: %v", index, err)
+	}
+	return derivations, nil
+}
+
+// readDerivations parses the derivations of the map whose key origin is the
+// fingerprint. A BIP-32 derivation value is the fingerprint followed by the
+// path, a taproot one prefixes them with the leaf hashes of the key.
+func readDerivations(m PSBTMap, bip32Type byte, tapType byte, fingerprint []byte) ([]*PSBTDerivation, error) {
+	derivations := []*PSBTDerivation{}
+	for _, pair := range m {
+		var origin []byte
+		switch pair.Key[0] {
+		case bip32Type:
+			origin = pair.Value
+		case tapType:
+			r := bytes.NewReader(pair.Value)
+			count, err := wire.ReadVarInt(r, 0)
+			if err != nil || count > uint64(r.Len()/32) {
+				return nil, fmt.Errorf("invalid leaf hashes of key %x", pair.Key[1:])
+			}
+			origin = pair.Value[len(pair.Value)-r.Len()+int(count)*32:]
+		default:
+			continue
+		}
+
+		if len(origin) < 4 || len(origin)%4 != 0 {
+			return nil, fmt.Errorf("invalid key origin of key %x", pair.Key[1:])
+		}
+		if !bytes.Equal(origin[:4], fingerprint) {
+			continue
+		}
+
+		path := make(accounts.DerivationPath, 0, len(origin)/4-1)
+		for i := 4; i < len(origin); i += 4 {
+			path = append(path, binary.LittleEndian.Uint32(origin[i:]))
+		}
+		derivations = append(derivations, &PSBTDerivation{
+			PublicKey: pair.Key[1:],
+			Path:      path,
+			Taproot:   pair.Key[0] == tapType,
+		})
+	}
+	return derivations, nil
+}
+
+// Matches reports whether the derivation is for the public key
+func (d *PSBTDerivation) Matches(publicKey *btcec.PublicKey) bool {
+	if d.Taproot {
+		return bytes.Equal(d.PublicKey, XOnlyPublicKey(publicKey))
+	}
+	return bytes.Equal(d.PublicKey, publicKey.SerializeCompressed())
+}
+
+// IsFinalized reports whether the input carries its final scripts
+func (p *PSBT) IsFinalized(index int) bool {
+	input := p.Inputs[index]
+	return input.Get(PSBTInFinalScriptSig) != nil || input.Get(PSBTInFinalScriptWitness) != nil
+}
+
+// SignInput signs the input if it spends an output paying to the key. It
+// returns the script type of the spent output and whether it has been signed.
+// Sighash types other than SIGHASH_ALL and SIGHASH_DEFAULT are refused unless
+// allowAnySighash is set.
+func (p *PSBT) SignInput(index int, privateKey *btcec.PrivateKey, allowAnySighash bool) (string, bool, error) {
+	prevout, err := p.Prevout(index)
+	if err != nil {
+		return "", false, err
+	}
+	if prevout == nil {
+		return ScriptTypeUnknown, false, nil
+	}
+
+	input := p.Inputs[index]
+	publicKey := privateKey.PubKey()
+	compressed := publicKey.SerializeCompressed()
+	pubKeyHash := btcutil.Hash160(compressed)
+	scriptType, program := ClassifyScript(prevout.PkScript)
+
+	switch scriptType {
+	case ScriptTypeP2PKH:
+		if !bytes.Equal(program, pubKeyHash) {
+			return scriptType, false, nil
+		}
+		return scriptType, true, p.signLegacy(index, allowAnySighash, prevout.PkScript, privateKey)
+	case ScriptTypeP2WPKH:
+		if !bytes.Equal(program, pubKeyHash) {
+			return scriptType, false, nil
+		}
+		return scriptType, true, p.signWitnessV0(index, allowAnySighash, prevout, prevout.PkScript, privateKey)
+	case ScriptTypeP2WSH:
+		witnessScript := input.Get(PSBTInWitnessScript)
+		if witnessScript == nil || !scriptHashMatches(program, witnessScript) || !scriptHasKey(witnessScript, compressed) {
+			return scriptType, false, nil
+		}
+		return scriptType, true, p.signWitnessV0(index, allowAnySighash, prevout, witnessScript, privateKey)
+	case ScriptTypeP2SH:
+		redeemScript := input.Get(PSBTInRedeemScript)
+		if redeemScript == nil || !bytes.Equal(program, btcutil.Hash160(redeemScript)) {
+			return scriptType, false, nil
+		}
+
+		nestedType, nestedProgram := ClassifyScript(redeemScript)
+		switch nestedType {
+		case ScriptTypeP2WPKH:
+			if !bytes.Equal(nestedProgram, pubKeyHash) {
+				return scriptType, false, nil
+			}
+			return scriptType + "-" + nestedType, true, p.signWitnessV0(index, allowAnySighash, prevout, redeemScript, privateKey)
+		case ScriptTypeP2WSH:
+			witnessScript := input.Get(PSBTInWitnessScript)
+			if witnessScript == nil || !scriptHashMatches(nestedProgram, witnessScript) || !scriptHasKey(witnessScript, compressed) {
+				return scriptType, false, nil
+			}
+			return scriptType + "-" + nestedType, true, p.signWitnessV0(index, allowAnySighash, prevout, witnessScript, privateKey)
+		}
+		if !scriptHasKey(redeemScript, compressed) {
+			return scriptType, false, nil
+		}
+		return scriptType, true, p.signLegacy(index, allowAnySighash, redeemScript, privateKey)
+	case ScriptTypeP2TR:
+		merkleRoot := input.Get(PSBTInTapMerkleRoot)
+		outputKey, err := TaprootOutputKey(publicKey, merkleRoot)
+		if err != nil {
+			return scriptType, false, err
+		}
+		if !bytes.Equal(program, outputKey) {
+			return scriptType, false, nil
+		}
+		return scriptType, true, p.signTaprootKeyPath(index, allowAnySighash, merkleRoot, privateKey)
+	}
+
+	return scriptType, false, nil
+}
+
+func (p *PSBT) signLegacy(index int, allowAnySighash bool, subScript []byte, privateKey *btcec.PrivateKey) error {
+	if p.Inputs[index].Get(PSBTInNonWitnessUTXO) == nil {
+		return fmt.Errorf("legacy input %d has no non-witness utxo", index)
+	}
+
+	hashType, err := p.signingSighashType(index, false, allowAnySighash)
+	if err != nil {
+		return err
+	}
+
+	signature, err := txscript.RawTxInSignature(p.UnsignedTx, index, subScript, txscript.SigHashType(hashType), privateKey)
+	if err != nil {
+		return err
+	}
+
+	p.Inputs[index].Set(append([]byte{PSBTInPartialSig}, privateKey.PubKey().SerializeCompressed()...), signature)
+	return nil
+}
+
+func (p *PSBT) signWitnessV0(index int, allowAnySighash bool, prevout *PSBTPrevout, subScript []byte, privateKey *btcec.PrivateKey) error {
+	// a segwit v0 signature commits to the amount of its own input only, which
+	// a forged witness utxo could understate to hide the fee
+	if !prevout.Verified {
+		return fmt.Errorf("segwit v0 input %d has no non-witness utxo", index)
+	}
+
+	hashType, err := p.signingSighashType(index, false, allowAnySighash)
+	if err != nil {
+		return err
+	}
+
+	sigHashes := txscript.NewTxSigHashes(p.UnsignedTx)
+	signature, err := txscript.RawTxInWitnessSignature(p.UnsignedTx, sigHashes, index, prevout.Amount, subScript, txscript.SigHashType(hashType), privateKey)
+	if err != nil {
+		return err
+	}
+
+	p.Inputs[index].Set(append([]byte{PSBTInPartialSig}, privateKey.PubKey().SerializeCompressed()...), signature)
+	return nil
+}
+
+func (p *PSBT) signTaprootKeyPath(index int, allowAnySighash bool, merkleRoot []byte, privateKey *btcec.PrivateKey) error {
+	hashType, err := p.signingSighashType(index, true, allowAnySighash)
+	if err != nil {
+		return err
+	}
+
+	sigHash, err := p.TaprootSigHash(index, hashType)
+	if err != nil {
+		return err
+	}
+
+	tweaked, err := TaprootTweakPrivateKey(privateKey, merkleRoot)
+	if err != nil {
+		return err
+	}
+
+	signature, err := SchnorrSign(tweaked, sigHash)
+	if err != nil {
+		return err
+	}
+	if hashType != sigHashDefault {
+		signature = append(signature, byte(hashType))
+	}
+
+	p.Inputs[index].Set([]byte{PSBTInTapKeySig}, signature)
+	return nil
+}
+
+// TaprootSigHash returns the BIP-341 key path signature hash of the input
+func (p *PSBT) TaprootSigHash(index int, hashType uint32) ([]byte, error) {
+	switch hashType {
+	case 0x00, 0x01, 0x02, 0x03, 0x81, 0x82, 0x83:
+	default:
+		return nil, fmt.Errorf("invalid taproot sighash type %d", hashType)
+	}
+	anyoneCanPay := hashType&uint32(txscript.SigHashAnyOneCanPay) != 0
+	outputType := hashType & 0x03
+	tx := p.UnsignedTx
+
+	prevouts := make([]*PSBTPrevout, len(tx.TxIn))
+	for i := range tx.TxIn {
+		if anyoneCanPay && i != index {
+			continue
+		}
+		prevout, err := p.Prevout(i)
+		if err != nil {
+			return nil, err
+		}
+		if prevout == nil {
+			return nil, fmt.Errorf("taproot signing needs the utxo of input %d", i)
+		}
+		prevouts[i] = prevout
+	}
+
+	var msg bytes.Buffer
+	// epoch
+	msg.WriteByte(0x00)
+	msg.WriteByte(byte(hashType))
+	binary.Write(&msg, binary.LittleEndian, tx.Version)
+	binary.Write(&msg, binary.LittleEndian, tx.LockTime)
+
+	if !anyoneCanPay {
+		var outpoints, amounts, scripts, sequences bytes.Buffer
+		for i, txIn := range tx.TxIn {
+			outpoints.Write(txIn.PreviousOutPoint.Hash[:])
+			binary.Write(&outpoints, binary.LittleEndian, txIn.PreviousOutPoint.Index)
+			binary.Write(&amounts, binary.LittleEndian, prevouts[i].Amount)
+			wire.WriteVarBytes(&scripts, 0, prevouts[i].PkScript)
+			binary.Write(&sequences, binary.LittleEndian, txIn.Sequence)
+		}
+		for _, data := range [][]byte{outpoints.Bytes(), amounts.Bytes(), scripts.Bytes(), sequences.Bytes()} {
+			hash := sha256.Sum256(data)
+			msg.Write(hash[:])
+		}
+	}
+
+	if outputType != uint32(txscript.SigHashNone) && outputType != uint32(txscript.SigHashSingle) {
+		var outputs bytes.Buffer
+		for _, txOut := range tx.TxOut {
+			wire.WriteTxOut(&outputs, 0, 0, txOut)
+		}
+		hash := sha256.Sum256(outputs.Bytes())
+		msg.Write(hash[:])
+	}
+
+	// spend type: key path without annex
+	msg.WriteByte(0x00)
+
+	if anyoneCanPay {
+		txIn := tx.TxIn[index]
+		msg.Write(txIn.PreviousOutPoint.Hash[:])
+		binary.Write(&msg, binary.LittleEndian, txIn.PreviousOutPoint.Index)
+		binary.Write(&msg, binary.LittleEndian, prevouts[index].Amount)
+		wire.WriteVarBytes(&msg, 0, prevouts[index].PkScript)
+		binary.Write(&msg, binary.LittleEndian, txIn.Sequence)
+	} else {
+		binary.Write(&msg, binary.LittleEndian, uint32(index))
+	}
+
+	if outputType == uint32(txscript.SigHashSingle) {
+		if index >= len(tx.TxOut) {
+			return nil, fmt.Errorf("input %d has no output for SIGHASH_SINGLE", index)
+		}
+		var output bytes.Buffer
+		wire.WriteTxOut(&output, 0, 0, tx.TxOut[index])
+		hash := sha256.Sum256(output.Bytes())
+		msg.Write(hash[:])
+	}
+
+	return TaggedHash("TapSighash", msg.Bytes()), nil
+}
+
+// FinalizeInput builds the final scripts of a single key input signed by the key,
+// dropping the fields only needed while signing. It reports whether the input has
+// been finalized.
+func (p *PSBT) FinalizeInput(index int, publicKey *btcec.PublicKey) (bool, error) {
+	if p.IsFinalized(index) {
+		return true, nil
+	}
+
+	prevout, err := p.Prevout(index)
+	if err != nil || prevout == nil {
+		return false, err
+	}
+
+	input := p.Inputs[index]
+	compressed := publicKey.SerializeCompressed()
+	partialSig := input.Get(append([]byte{PSBTInPartialSig}, compressed...)...)
+
+	var scriptSig []byte
+	var witness wire.TxWitness
+	scriptType, _ := ClassifyScript(prevout.PkScript)
+	switch scriptType {
+	case ScriptTypeP2PKH:
+		if partialSig == nil {
+			return false, nil
+		}
+		scriptSig, err = txscript.NewScriptBuilder().AddData(partialSig).AddData(compressed).Script()
+		if err != nil {
+			return false, err
+		}
+	case ScriptTypeP2WPKH:
+		if partialSig == nil {
+			return false, nil
+		}
+		witness = wire.TxWitness{partialSig, compressed}
+	case ScriptTypeP2SH:
+		redeemScript := input.Get(PSBTInRedeemScript)
+		if nestedType, _ := ClassifyScript(redeemScript); partialSig == nil || nestedType != ScriptTypeP2WPKH {
+			return false, nil
+		}
+		scriptSig, err = txscript.NewScriptBuilder().AddData(redeemScript).Script()
+		if err != nil {
+			return false, err
+		}
+		witness = wire.TxWitness{partialSig, compressed}
+	case ScriptTypeP2TR:
+		tapKeySig := input.Get(PSBTInTapKeySig)
+		if tapKeySig == nil {
+			return false, nil
+		}
+		witness = wire.TxWitness{tapKeySig}
+	default:
+		return false, nil
+	}
+
+	finalized := PSBTMap{}
+	for _, pair := range input {
+		keyType := pair.Key[0]
+		if keyType == PSBTInNonWitnessUTXO || keyType == PSBTInWitnessUTXO || keyType > PSBTInTapMerkleRoot {
+			finalized = append(finalized, pair)
+		}
+	}
+	if scriptSig != nil {
+		finalized.Set([]byte{PSBTInFinalScriptSig}, scriptSig)
+	}
+	if witness != nil {
+		var buf bytes.Buffer
+		if err := writeWitness(&buf, witness); err != nil {
+			return false, err
+		}
+		finalized.Set([]byte{PSBTInFinalScriptWitness}, buf.Bytes())
+	}
+	p.Inputs[index] = finalized
+
+	return true, nil
+}
+
+// Extract returns the network serialized transaction of a PSBT whose inputs are all finalized
+func (p *PSBT) Extract() (*wire.MsgTx, error) {
+	tx := p.UnsignedTx.Copy()
+	for i, input := range p.Inputs {
+		if !p.IsFinalized(i) {
+			return nil, fmt.Errorf("input %d is not finalized", i)
+		}
+		tx.TxIn[i].SignatureScript = input.Get(PSBTInFinalScriptSig)
+		if value := input.Get(PSBTInFinalScriptWitness); value != nil {
+			witness, err := readWitness(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid final script witness of input %d: %v", i, err)
+			}
+			tx.TxIn[i].Witness = witness
+		}
+	}
+	return tx, nil
+}
+
+// ClassifyScript returns the type of the output script with its hash or witness program
+func ClassifyScript(script []byte) (string, []byte) {
+	switch {
+	case len(script) == 25 && script[0] == txscript.OP_DUP && script[1] == txscript.OP_HASH160 &&
+		script[2] == txscript.OP_DATA_20 && script[23] == txscript.OP_EQUALVERIFY && script[24] == txscript.OP_CHECKSIG:
+		return ScriptTypeP2PKH, script[3:23]
+	case len(script) == 23 && script[0] == txscript.OP_HASH160 && script[1] == txscript.OP_DATA_20 && script[22] == txscript.OP_EQUAL:
+		return ScriptTypeP2SH, script[2:22]
+	case len(script) == 22 && script[0] == txscript.OP_0 && script[1] == txscript.OP_DATA_20:
+		return ScriptTypeP2WPKH, script[2:]
+	case len(script) == 34 && script[0] == txscript.OP_0 && script[1] == txscript.OP_DATA_32:
+		return ScriptTypeP2WSH, script[2:]
+	case len(script) == 34 && script[0] == txscript.OP_1 && script[1] == txscript.OP_DATA_32:
+		return ScriptTypeP2TR, script[2:]
+	}
+	return ScriptTypeUnknown, nil
+}
+
+// PayToAddressScript returns the output script paying to the address of the account
+func PayToAddressScript(account *Account) ([]byte, error) {
+	publicKeyBytes := common.FromHex(account.PublicKey)
+	publicKey, err := btcec.ParsePubKey(publicKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	return PayToPublicKeyScript(publicKey, account.AddressType)
+}
+
+// PayToPublicKeyScript returns the output script paying to the address of the type of the public key
+func PayToPublicKeyScript(publicKey *btcec.PublicKey, addressType string) ([]byte, error) {
+	pubKeyHash := btcutil.Hash160(publicKey.SerializeCompressed())
+
+	builder := txscript.NewScriptBuilder()
+	switch addressType {
+	case AddressTypeP2PKH:
+		builder.AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160).AddData(pubKeyHash).AddOp(txscript.OP_EQUALVERIFY).AddOp(txscript.OP_CHECKSIG)
+	case AddressTypeP2SHP2WPKH:
+		redeemScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(pubKeyHash).Script()
+		if err != nil {
+			return nil, err
+		}
+		builder.AddOp(txscript.OP_HASH160).AddData(btcutil.Hash160(redeemScript)).AddOp(txscript.OP_EQUAL)
+	case AddressTypeP2WPKH:
+		builder.AddOp(txscript.OP_0).AddData(pubKeyHash)
+	case AddressTypeP2TR:
+		outputKey, err := TaprootOutputKey(publicKey, nil)
+		if err != nil {
+			return nil, err
+		}
+		builder.AddOp(txscript.OP_1).AddData(outputKey)
+	default:
+		return nil, fmt.Errorf("unsupported bitcoin address type %s", addressType)
+	}
+	return builder.Script()
+}
+
+func scriptHashMatches(program []byte, witnessScript []byte) bool {
+	hash := sha256.Sum256(witnessScript)
+	return bytes.Equal(program, hash[:])
+}
+
+// scriptHasKey reports whether the script pushes the compressed public key
+func scriptHasKey(script []byte, compressed []byte) bool {
+	pushes, err := txscript.PushedData(script)
+	if err != nil {
+		return false
+	}
+	for _, push := range pushes {
+		if bytes.Equal(push, compressed) {
+			return true
+		}
+	}
+	return false
+}
+
+func readTxOut(value []byte) (*wire.TxOut, error) {
+	r := bytes.NewReader(value)
+	var amount int64
+	if err := binary.Read(r, binary.LittleEndian, &amount); err != nil {
+		return nil, err
+	}
+	pkScript, err := wire.ReadVarBytes(r, 0, uint32(r.Len()), "pkScript")
+	if err != nil {
+		return nil, err
+	}
+	return wire.NewTxOut(amount, pkScript), nil
+}
+
+func writeWitness(w io.Writer, witness wire.TxWitness) error {
+	if err := wire.WriteVarInt(w, 0, uint64(len(witness))); err != nil {
+		return err
+	}
+	for _, item := range witness {
+		if err := wire.WriteVarBytes(w, 0, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readWitness(value []byte) (wire.TxWitness, error) {
+	r := bytes.NewReader(value)
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if count > uint64(len(value)) {
+		return nil, errors.New("too many witness items")
+	}
+	witness := make(wire.TxWitness, 0, count)
+	for i := uint64(0); i < count; i++ {
+		item, err := wire.ReadVarBytes(r, 0, uint32(len(value)), "witness item")
+		if err != nil {
+			return nil, err
+		}
+		witness = append(witness, item)
+	}
+	return witness, nil
+}
@@ -0,0 +1,294 @@
+package model
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// BIP-341 key path spends of the Bitcoin Core script_assets_test set, one per
+// sighash type, whose signatures must verify against the computed sighash
+var taprootSigHashVectors = []struct {
+	tx        string
+	prevouts  []string
+	index     int
+	signature string
+}{
+	{
+		// sighash/keypath_hashtype_0
+		tx: "0200000002dff9d694a434b13abfbbd618e2ece4460f24b4821cf47d5afc481a386c59565c3c0000000017ddeeecdff9d694a434b13abfbbd618e2ece4460f24b4821cf47d5afc481a386c59565cfb0100000096479ad303c0b99c00000000001600149d38710eb90e420b159c7a9263994c88e6810bc758020000000000001976a9145dabd582fbdb106f3f7460c03ce83bc27d461d0f88ac58020000000000001976a91401f109af244d8c7f2563284ac2d2ba7d6323a75e88ac86e9c54b",
+		prevouts: []string{
+			"6d6a48000000000022512012b975b505febce3d90537f513ce86dc778c6aa76aa4c7c143b3b99f1662d22e",
+			"fe56570000000000225120bb7ba78fb938249831f92608d0f71e24d86e7660c51dd93d52c4bb7a103fd2d9",
+		},
+		index:     0,
+		signature: "93765305a3fae08d9a1b1d28b4b2065aa3d6f1031fd31a5e3b926f65d534a5dce6eeb59b0d59e42719939f6e7d4ce9883d9276137c979d255bd3c1c6af7c6335",
+	},
+	{
+		// sighash/keypath_hashtype_1
+		tx: "0100000002bcb2054607a921b3c6df992a9486776863b28485e731a805931b6feb14221acf4901000000049cf49e8bd9b9012d1e9d0bc9c34df9d487a1d5663f1b37dbd4a857a2bddcbe25f0d0c4b801000000bc4daa160275809a0000000000160014f19f1969da9e474444a7b8fc50ae71f46e1eb7965802000000000000160014619b982e9f6832d2edb1a1ee4e7656a8d72c65e754000000",
+		prevouts: []string{
+			"0c3a6400000000002251205327380047190b39068e361063e76c0639ec95616567f9015a7792cf50895358",
+			"53e838000000000022512012b975b505febce3d90537f513ce86dc778c6aa76aa4c7c143b3b99f1662d22e",
+		},
+		index:     1,
+		signature: "bfa2103ccccf1488b36a28aa4957dfd6becf11a8a0469d582e7c98bc6255926c5affaaa2f1dddc4c29a1c8e96b141211a3426a1da8693f2e73f2f57fa23d7bde01",
+	},
+	{
+		// sighash/keypath_hashtype_2
+		tx: "4431cfc6028bd9b9012d1e9d0bc9c34df9d487a1d5663f1b37dbd4a857a2bddcbe25f0d0c40701000000be5f58b98bd9b9012d1e9d0bc9c34df9d487a1d5663f1b37dbd4a857a2bddcbe25f0d0c4bf000000000206778f03fb9a7700000000001976a914c629d61df58baceae110d15eb5b55e144268615388ac580200000000000016001428425a8aab0a57cd9398c2c78c3d097fe1a397a6580200000000000017a914f017945d4d088c7d42ab3bcbc1adce51d74fbd9f871feb932f",
+		prevouts: []string{
+			"fede3f000000000022512012b975b505febce3d90537f513ce86dc778c6aa76aa4c7c143b3b99f1662d22e",
+			"5c57390000000000225120b5fac7f9d1efa21092b4bbfea1ca41fe5694dd20d67936ab2b478b1ec4aee588",
+		},
+		index:     0,
+		signature: "c98207f6e7e605a3700ebff3688f2b4769adf8b0d5ca1032dde96a951df98a8e0d478d3835bf3b542e9237d546024a898fc4cb8780c754bf7f55cc5d2d7ac8bb02",
+	},
+	{
+		// sighash/keypath_hashtype_3
+		tx: "0100000003bcb2054607a921b3c6df992a9486776863b28485e731a805931b6feb14221acf21000000004676830d8bd9b9012d1e9d0bc9c34df9d487a1d5663f1b37dbd4a857a2bddcbe25f0d0c41702000000bbb7591260f8b8616e71e7ed05613145ce7cda782ac9861e64f9ce24e333ca1e91d912707600000000094760f703733ebe000000000016001428425a8aab0a57cd9398c2c78c3d097fe1a397a658020000000000001976a91490770ceff2b1c32e9dbf952fbe65b04a54d1949388ac5802000000000000160014deb4696df95e4685eae8f9ff2e77fc7edabbe2fcc56f0646",
+		prevouts: []string{
+			"044877000000000017a914b1a54d09172ecbb89289f2a670acc3fe14ced9ee87",
+			"4656390000000000225120f46c27e4be4b28b9a4817d4bb21e6d76e9bff45d28c4e23d061d7fc56326d512",
+			"ea96100000000000225120c72d052844e54654bf1b4ba7d482e0a32ceacfdb2b793a896c2e00e5d00b606a",
+		},
+		index:     2,
+		signature: "d7b6456f201cf74ae23f528fa19076a87cd6787b60d78d7f8cae6ede245595824cce38957e3567f0634717543ddf7bae1e1d4620d2ac610a556e520ac5bfe7c303",
+	},
+	{
+		// sighash/keypath_hashtype_81
+		tx: "0100000002bcb2054607a921b3c6df992a9486776863b28485e731a805931b6feb14221acfe701000000566f672760f8b8616e71e7ed05613145ce7cda782ac9861e64f9ce24e333ca1e91d912706e01000000333580ee0250697b00000000001976a914c629d61df58baceae110d15eb5b55e144268615388ac5802000000000000160014deb4696df95e4685eae8f9ff2e77fc7edabbe2fcb141a65f",
+		prevouts: []string{
+			"7df96d000000000022512012b975b505febce3d90537f513ce86dc778c6aa76aa4c7c143b3b99f1662d22e",
+			"c049100000000000225120f31e3a320eea15b969f8b18ed69a6dfb33cc054a2307ba2bd3877db1ef9fdc39",
+		},
+		index:     0,
+		signature: "0e6ae66a3f51db8c3cea8d4b020e7486e5e480dbd6f5db160cf5d1c224418958ce5aa0eea06f972ed12f6fc3975b440cee50491ac1499c5a8052daf0c743250a81",
+	},
+	{
+		// sighash/keypath_hashtype_82
+		tx: "5f8a773c01bcb2054607a921b3c6df992a9486776863b28485e731a805931b6feb14221acf7e000000002bdb96d603a0746a000000000016001428425a8aab0a57cd9398c2c78c3d097fe1a397a65802000000000000160014619b982e9f6832d2edb1a1ee4e7656a8d72c65e758020000000000001976a914c629d61df58baceae110d15eb5b55e144268615388acbb000000",
+		prevouts: []string{
+			"f7196d000000000022512024241b8c28db08f46e2039187a480378b2a1ee734bde764c6e80647709b09b47",
+		},
+		index:     0,
+		signature: "121729a4e5066a4248b812517e0edf3437d4c4b2f3ba9a2e78c1807293563fee00376f779a717136521a6b2fcb1e81a50797141f82f607249310e6061e267f6082",
+	},
+	{
+		// sighash/keypath_hashtype_83
+		tx: "0200000003dceb5f5568f8ada45d428630f512fb8efacd46682b4367b4edaf1985c5e4af4b8d000000009628e1ab8bd9b9012d1e9d0bc9c34df9d487a1d5663f1b37dbd4a857a2bddcbe25f0d0c4f10100000041654ba460f8b8616e71e7ed05613145ce7cda782ac9861e64f9ce24e333ca1e91d91270c200000000352cd7d102283e7a00000000001976a91490770ceff2b1c32e9dbf952fbe65b04a54d1949388ac5802000000000000160014619b982e9f6832d2edb1a1ee4e7656a8d72c65e7eca46228",
+		prevouts: []string{
+			"be4828000000000022512081fe6bd81c93a76bc00ce825f56a69a98e925b76c72731e1070d37ac4d963490",
+			"99de42000000000022512012b975b505febce3d90537f513ce86dc778c6aa76aa4c7c143b3b99f1662d22e",
+			"344811000000000017a914d574841bde7bf0817694c799002118e85acf040e87",
+		},
+		index:     1,
+		signature: "67b122669ea9d7a03ac499c300412e9a4808c17efdf9b008efa5cae7ffb3525adfe5f7840f42178b593fba90e1011296da951f40f07e6762457d4c69363de8f883",
+	},
+}
+
+func TestTaprootSigHash(t *testing.T) {
+	for _, vector := range taprootSigHashVectors {
+		tx := wire.NewMsgTx(wire.TxVersion)
+		if err := tx.Deserialize(bytes.NewReader(mustDecodeHex(t, vector.tx))); err != nil {
+			t.Fatal(err)
+		}
+		packet := &PSBT{UnsignedTx: tx}
+		for i, txIn := range tx.TxIn {
+			txIn.SignatureScript = nil
+			txIn.Witness = nil
+			packet.Inputs = append(packet.Inputs, PSBTMap{{Key: []byte{PSBTInWitnessUTXO}, Value: mustDecodeHex(t, vector.prevouts[i])}})
+		}
+
+		signature := mustDecodeHex(t, vector.signature)
+		hashType := uint32(sigHashDefault)
+		if len(signature) == 65 {
+			hashType = uint32(signature[64])
+		}
+
+		sigHash, err := packet.TaprootSigHash(vector.index, hashType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prevout, err := packet.Prevout(vector.index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !SchnorrVerify(prevout.PkScript[2:], sigHash, signature[:64]) {
+			t.Errorf("signature of hash type %#x does not verify against the sighash %x", hashType, sigHash)
+		}
+	}
+}
+
+// testWitnessV0PSBT returns a PSBT spending the P2WPKH output of the account,
+// with the previous transaction as non-witness utxo
+func testWitnessV0PSBT(t *testing.T, account *Account, amount int64) (*PSBT, *wire.MsgTx) {
+	t.Helper()
+	pkScript, err := PayToAddressScript(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prevTx := wire.NewMsgTx(wire.TxVersion)
+	prevTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), []byte{txscript.OP_TRUE}, nil))
+	prevTx.AddTxOut(wire.NewTxOut(1000, []byte{txscript.OP_TRUE}))
+	prevTx.AddTxOut(wire.NewTxOut(amount, pkScript))
+
+	prevHash := prevTx.TxHash()
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevHash, 1), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(amount-1000, []byte{txscript.OP_RETURN}))
+
+	var prevTxBytes bytes.Buffer
+	if err := prevTx.Serialize(&prevTxBytes); err != nil {
+		t.Fatal(err)
+	}
+	return &PSBT{
+		UnsignedTx: tx,
+		Inputs:     []PSBTMap{{{Key: []byte{PSBTInNonWitnessUTXO}, Value: prevTxBytes.Bytes()}}},
+		Outputs:    []PSBTMap{{}},
+	}, prevTx
+}
+
+func testWitnessUTXO(t *testing.T, amount int64, pkScript []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := wire.WriteTxOut(&buf, 0, 0, wire.NewTxOut(amount, pkScript)); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSignWitnessV0(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := wallet.DeriveBitcoin(MustParseDerivationPath("m/84'/0'/0'/0/0"), AddressTypeP2WPKH, NetworkMainnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, account.PrivateKey))
+
+	packet, prevTx := testWitnessV0PSBT(t, account, 50000)
+	pkScript := prevTx.TxOut[1].PkScript
+	packet.Inputs[0].Set([]byte{PSBTInWitnessUTXO}, testWitnessUTXO(t, 50000, pkScript))
+
+	// the PSBT survives a round trip
+	encoded, err := packet.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	packet, err = DecodePSBT(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scriptType, signed, err := packet.SignInput(0, privateKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scriptType != ScriptTypeP2WPKH || !signed {
+		t.Fatalf("got script type %s signed %v, want %s signed", scriptType, signed, ScriptTypeP2WPKH)
+	}
+	if finalized, err := packet.FinalizeInput(0, privateKey.PubKey()); err != nil || !finalized {
+		t.Fatalf("input is not finalized: %v", err)
+	}
+
+	tx, err := packet.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine, err := txscript.NewEngine(pkScript, tx, 0, txscript.StandardVerifyFlags, nil, txscript.NewTxSigHashes(tx), 50000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Execute(); err != nil {
+		t.Fatalf("signed transaction does not verify: %v", err)
+	}
+}
+
+func TestSignWitnessV0RequiresNonWitnessUTXO(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := wallet.DeriveBitcoin(MustParseDerivationPath("m/84'/0'/0'/0/0"), AddressTypeP2WPKH, NetworkMainnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, account.PrivateKey))
+	pkScript, err := PayToAddressScript(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a witness utxo alone is not trusted
+	packet, _ := testWitnessV0PSBT(t, account, 50000)
+	packet.Inputs[0] = PSBTMap{{Key: []byte{PSBTInWitnessUTXO}, Value: testWitnessUTXO(t, 50000, pkScript)}}
+	if _, _, err := packet.SignInput(0, privateKey, false); err == nil || !strings.Contains(err.Error(), "no non-witness utxo") {
+		t.Errorf("got error %v, want missing non-witness utxo", err)
+	}
+
+	// a witness utxo understating the amount of the non-witness utxo
+	packet, _ = testWitnessV0PSBT(t, account, 50000)
+	packet.Inputs[0].Set([]byte{PSBTInWitnessUTXO}, testWitnessUTXO(t, 1000, pkScript))
+	if _, err := packet.Prevout(0); err == nil {
+		t.Error("mismatching witness utxo is accepted")
+	}
+
+	// amounts beyond the supply
+	packet, _ = testWitnessV0PSBT(t, account, btcutil.MaxSatoshi+1)
+	if _, err := packet.Prevout(0); err == nil {
+		t.Error("out of range amount is accepted")
+	}
+}
+
+func TestInputDerivations(t *testing.T) {
+	fingerprint := mustDecodeHex(t, "73c5da0a")
+	origin := func(fingerprint []byte, path ...uint32) []byte {
+		value := append([]byte{}, fingerprint...)
+		for _, n := range path {
+			var index [4]byte
+			binary.LittleEndian.PutUint32(index[:], n)
+			value = append(value, index[:]...)
+		}
+		return value
+	}
+
+	compressed := mustDecodeHex(t, "0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c")
+	xOnly := compressed[1:]
+	leafHash := bytes.Repeat([]byte{0xab}, 32)
+	path := MustParseDerivationPath("m/84'/0'/0'/1/7")
+
+	packet := &PSBT{Inputs: []PSBTMap{{
+		{Key: append([]byte{PSBTInBIP32Derivation}, compressed...), Value: origin(fingerprint, path...)},
+		{Key: append([]byte{PSBTInTapBIP32Derivation}, xOnly...), Value: append(append([]byte{1}, leafHash...), origin(fingerprint, path...)...)},
+		{Key: append([]byte{PSBTInBIP32Derivation}, bytes.Repeat([]byte{0x02}, 33)...), Value: origin([]byte{1, 2, 3, 4}, path...)},
+	}}}
+
+	derivations, err := packet.InputDerivations(0, fingerprint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(derivations) != 2 {
+		t.Fatalf("got %d derivations, want 2", len(derivations))
+	}
+	for i, want := range [][]byte{compressed, xOnly} {
+		if !bytes.Equal(derivations[i].PublicKey, want) || derivations[i].Path.String() != path.String() || derivations[i].Taproot != (i == 1) {
+			t.Errorf("derivation %d is %x %s, want %x %s", i, derivations[i].PublicKey, derivations[i].Path, want, path)
+		}
+	}
+
+	packet.Inputs[0] = PSBTMap{{Key: append([]byte{PSBTInTapBIP32Derivation}, xOnly...), Value: []byte{2, 0xab}}}
+	if _, err := packet.InputDerivations(0, fingerprint); err == nil {
+		t.Error("truncated leaf hashes are accepted")
+	}
+}
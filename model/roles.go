@@ -0,0 +1,137 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// RolesPrefix is the storage prefix of the signing roles
+const RolesPrefix = "roles/"
+
+// SigningOperations are the account paths producing signatures, which roles restrict
+var SigningOperations = []string{
+	"sign",
+	"sign-tx",
+	"sign-erc20-transfer",
+	"sign-erc20-approve",
+	"sign-siwe",
+	"sign-safe-tx",
+	"sign-user-operation",
+	"sign-psbt",
+	"sign-message",
+	"sign-solana-tx",
+	"sign-cosmos",
+	"sign-tron-tx",
+	"sign-xrpl-tx",
+}
+
+// Role restricts the signatures requested under it. Empty restrictions allow anything.
+type Role struct {
+	Name string `json:"name"`
+
+	// Accounts are account names or glob patterns, e.g. treasury-*
+	Accounts   []string `json:"accounts,omitempty"`
+	ChainIDs   []string `json:"chainIDs,omitempty"`
+	Operations []string `json:"operations,omitempty"`
+
+	// MaxValue bounds the value of each signature, in the smallest unit of the chain
+	// or of the token transferred. Signatures without a value are refused.
+	MaxValue  string   `json:"maxValue,omitempty"`
+	AllowedTo []string `json:"allowedTo,omitempty"`
+}
+
+// ReadRole returns the role of the name, or nil if none is configured
+func ReadRole(ctx context.Context, storage logical.Storage, name string) (*Role, error) {
+	entry, err := storage.Get(ctx, RolesPrefix+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var role *Role
+	err = entry.DecodeJSON(&role)
+	if err != nil {
+		return nil, errors.New("Fail to decode role to JSON format")
+	}
+
+	return role, nil
+}
+
+// Validate checks every restriction is well formed
+func (r *Role) Validate() error {
+	for _, pattern := range r.Accounts {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid account pattern %s", pattern)
+		}
+	}
+
+	for _, operation := range r.Operations {
+		if !containsFold(SigningOperations, operation) {
+			return fmt.Errorf("unsupported operation %s", operation)
+		}
+	}
+
+	if _, err := parseWei(r.MaxValue); err != nil {
+		return fmt.Errorf("invalid max_value: %v", err)
+	}
+
+	return nil
+}
+
+// Check returns an error describing the first restriction the signature of
+// the account violates. The operation is the path of the request, e.g. sign-tx.
+func (r *Role) Check(account string, operation string, entry *HistoryEntry) error {
+	if len(r.Accounts) > 0 {
+		matched := false
+		for _, pattern := range r.Accounts {
+			if ok, _ := path.Match(pattern, account); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("role %s does not allow account %s", r.Name, account)
+		}
+	}
+
+	if len(r.Operations) > 0 && !containsFold(r.Operations, operation) {
+		return fmt.Errorf("role %s does not allow %s", r.Name, operation)
+	}
+
+	if len(r.ChainIDs) > 0 && !containsFold(r.ChainIDs, entry.ChainID) {
+		if entry.ChainID == "" {
+			return fmt.Errorf("role %s only allows chains %s, %s has no chain ID", r.Name, strings.Join(r.ChainIDs, ", "), operation)
+		}
+		return fmt.Errorf("role %s does not allow chain %s", r.Name, entry.ChainID)
+	}
+
+	if len(r.AllowedTo) > 0 && !containsFold(r.AllowedTo, entry.To) {
+		if entry.To == "" {
+			return fmt.Errorf("role %s only allows listed destinations, %s has none", r.Name, operation)
+		}
+		return fmt.Errorf("role %s does not allow destination %s", r.Name, entry.To)
+	}
+
+	if maxValue, _ := parseWei(r.MaxValue); maxValue != nil {
+		if entry.Value == "" {
+			return fmt.Errorf("role %s bounds the value of signatures, %s has none", r.Name, operation)
+		}
+		value, ok := new(big.Int).SetString(entry.Value, 10)
+		if !ok {
+			return fmt.Errorf("role %s cannot check the value %s against its max_value", r.Name, entry.Value)
+		}
+		if value.Cmp(maxValue) > 0 {
+			return fmt.Errorf("value %s exceeds the max_value %s of role %s", value, maxValue, r.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	safeTxTypeHash = crypto.Keccak256([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+
+	// Safe contracts before 1.3.0 do not include the chain ID in the domain
+	safeDomainTypeHash       = crypto.Keccak256([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	safeLegacyDomainTypeHash = crypto.Keccak256([]byte("EIP712Domain(address verifyingContract)"))
+)
+
+// SafeTransaction is a Gnosis Safe SafeTx to be confirmed by an owner
+type SafeTransaction struct {
+	Safe           common.Address
+	ChainID        *big.Int
+	Version        string
+	To             common.Address
+	Value          *big.Int
+	Data           []byte
+	Operation      uint8
+	SafeTxGas      *big.Int
+	BaseGas        *big.Int
+	GasPrice       *big.Int
+	GasToken       common.Address
+	RefundReceiver common.Address
+	Nonce          *big.Int
+}
+
+// DomainSeparator returns the EIP-712 domain separator of the Safe
+func (t *SafeTransaction) DomainSeparator() ([]byte, error) {
+	legacy, err := isLegacySafeVersion(t.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if legacy {
+		return crypto.Keccak256(
+			safeLegacyDomainTypeHash,
+			common.LeftPadBytes(t.Safe.Bytes(), 32),
+		), nil
+	}
+
+	return crypto.Keccak256(
+		safeDomainTypeHash,
+		math256(t.ChainID),
+		common.LeftPadBytes(t.Safe.Bytes(), 32),
+	), nil
+}
+
+// Hash returns the safeTxHash the owners sign
+func (t *SafeTransaction) Hash() (common.Hash, error) {
+	if t.Operation > 1 {
+		return common.Hash{}, fmt.Errorf("invalid operation %d, expected 0 (call) or 1 (delegatecall)", t.Operation)
+	}
+
+	domainSeparator, err := t.DomainSeparator()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	structHash := crypto.Keccak256(
+		safeTxTypeHash,
+		common.LeftPadBytes(t.To.Bytes(), 32),
+		math256(t.Value),
+		crypto.Keccak256(t.Data),
+		math256(new(big.Int).SetUint64(uint64(t.Operation))),
+		math256(t.SafeTxGas),
+		math256(t.BaseGas),
+		math256(t.GasPrice),
+		common.LeftPadBytes(t.GasToken.Bytes(), 32),
+		common.LeftPadBytes(t.RefundReceiver.Bytes(), 32),
+		math256(t.Nonce),
+	)
+
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, structHash), nil
+}
+
+// isLegacySafeVersion reports whether the Safe version predates 1.3.0, refusing versions before 1.0.0
+func isLegacySafeVersion(safeVersion string) (bool, error) {
+	parts := strings.Split(safeVersion, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return false, fmt.Errorf("invalid safe version %s", safeVersion)
+	}
+
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return false, fmt.Errorf("invalid safe version %s", safeVersion)
+		}
+		numbers[i] = number
+	}
+
+	// Safes before 1.0.0 name baseGas dataGas, which changes the SafeTx type hash
+	if numbers[0] < 1 {
+		return false, fmt.Errorf("safe version %s is not supported, Safes before 1.0.0 sign a different SafeTx type", safeVersion)
+	}
+
+	return numbers[0] == 1 && numbers[1] < 3, nil
+}
+
+// math256 encodes an unsigned integer as a 32 bytes word
+func math256(value *big.Int) []byte {
+	if value == nil {
+		return make([]byte, 32)
+	}
+	return common.LeftPadBytes(value.Bytes(), 32)
+}
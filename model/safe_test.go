@@ -0,0 +1,52 @@
+package model
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// safeTxHash of the same transaction for a 1.1.1 Safe, whose domain has no
+// chain ID, and for a 1.3.0 Safe, computed with the EIP-712 encoder of go-ethereum
+func TestSafeTransactionHash(t *testing.T) {
+	vectors := []struct {
+		version string
+		hash    string
+	}{
+		{"1.1.1", "0xf32e1ec67971152fc6b3d9a6770010bc21c9b90811dbd82ed5fc4454893d199e"},
+		{"1.3.0", "0x71ebf342e05d37e62fb2e128b8092017b9ee27d1785cddf29a2340b4bd74dd92"},
+	}
+
+	for _, vector := range vectors {
+		safeTx := &SafeTransaction{
+			Safe:      common.HexToAddress("0x5AFE3855358E112B5647B952709E6165e1c1eEEe"),
+			ChainID:   big.NewInt(5),
+			Version:   vector.version,
+			To:        common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+			Value:     big.NewInt(1000000000000000000),
+			Data:      hexutil.MustDecode("0xa9059cbb000000000000000000000000000000000000000000000000000000000000dead0000000000000000000000000000000000000000000000000000000000000064"),
+			SafeTxGas: big.NewInt(50000),
+			BaseGas:   big.NewInt(21000),
+			GasPrice:  big.NewInt(1000000000),
+			Nonce:     big.NewInt(7),
+		}
+
+		hash, err := safeTx.Hash()
+		if err != nil {
+			t.Fatalf("%s: %v", vector.version, err)
+		}
+		if hash.Hex() != vector.hash {
+			t.Errorf("%s: got safeTxHash %s, want %s", vector.version, hash.Hex(), vector.hash)
+		}
+	}
+}
+
+// Safes before 1.0.0 sign dataGas instead of baseGas
+func TestSafeTransactionHashRefusesPre100(t *testing.T) {
+	safeTx := &SafeTransaction{Version: "0.1.0", ChainID: big.NewInt(1)}
+	if _, err := safeTx.Hash(); err == nil {
+		t.Fatal("a 0.1.0 Safe transaction was hashed")
+	}
+}
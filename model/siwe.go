@@ -0,0 +1,145 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	siweSchemeRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+\-.]*$`)
+	siweNonceRegex  = regexp.MustCompile(`^[a-zA-Z0-9]{8,}$`)
+)
+
+// SIWEMessage is a Sign-In with Ethereum message as defined by EIP-4361
+type SIWEMessage struct {
+	Scheme         string
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        *big.Int
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// Validate checks the fields of the message against the EIP-4361 grammar
+func (m *SIWEMessage) Validate() error {
+	if m.Scheme != "" && !siweSchemeRegex.MatchString(m.Scheme) {
+		return fmt.Errorf("invalid scheme %q", m.Scheme)
+	}
+
+	if m.Domain == "" {
+		return errors.New("domain is required")
+	}
+	domain, err := url.Parse("placeholder://" + m.Domain)
+	if err != nil || domain.Host != m.Domain || domain.Hostname() == "" {
+		return fmt.Errorf("invalid domain %q, an RFC 3986 authority is expected", m.Domain)
+	}
+
+	if strings.ContainsAny(m.Statement, "\n\r") {
+		return errors.New("statement must not contain line breaks")
+	}
+
+	if err := validateSIWEURI(m.URI); err != nil {
+		return fmt.Errorf("invalid uri: %v", err)
+	}
+
+	if m.Version != "1" {
+		return fmt.Errorf("unsupported version %q", m.Version)
+	}
+
+	if m.ChainID == nil || m.ChainID.Sign() <= 0 {
+		return errors.New("chain ID must be a positive integer")
+	}
+
+	if !siweNonceRegex.MatchString(m.Nonce) {
+		return errors.New("nonce must be at least 8 alphanumeric characters")
+	}
+
+	if m.ExpirationTime != nil && !m.ExpirationTime.After(m.IssuedAt) {
+		return errors.New("expiration time must be after issued-at")
+	}
+	if m.ExpirationTime != nil && !m.ExpirationTime.After(time.Now()) {
+		return errors.New("expiration time is in the past")
+	}
+	if m.NotBefore != nil && m.ExpirationTime != nil && !m.ExpirationTime.After(*m.NotBefore) {
+		return errors.New("expiration time must be after not-before")
+	}
+
+	if strings.ContainsAny(m.RequestID, "\n\r") {
+		return errors.New("request ID must not contain line breaks")
+	}
+
+	for _, resource := range m.Resources {
+		if err := validateSIWEURI(resource); err != nil {
+			return fmt.Errorf("invalid resource %q: %v", resource, err)
+		}
+	}
+
+	return nil
+}
+
+// String renders the canonical EIP-4361 message
+func (m *SIWEMessage) String() string {
+	var builder strings.Builder
+
+	if m.Scheme != "" {
+		builder.WriteString(m.Scheme + "://")
+	}
+	builder.WriteString(m.Domain + " wants you to sign in with your Ethereum account:\n")
+	builder.WriteString(m.Address + "\n\n")
+	if m.Statement != "" {
+		builder.WriteString(m.Statement + "\n")
+	}
+	builder.WriteString("\n")
+
+	builder.WriteString("URI: " + m.URI + "\n")
+	builder.WriteString("Version: " + m.Version + "\n")
+	builder.WriteString("Chain ID: " + m.ChainID.String() + "\n")
+	builder.WriteString("Nonce: " + m.Nonce + "\n")
+	builder.WriteString("Issued At: " + m.IssuedAt.Format(time.RFC3339Nano))
+	if m.ExpirationTime != nil {
+		builder.WriteString("\nExpiration Time: " + m.ExpirationTime.Format(time.RFC3339Nano))
+	}
+	if m.NotBefore != nil {
+		builder.WriteString("\nNot Before: " + m.NotBefore.Format(time.RFC3339Nano))
+	}
+	if m.RequestID != "" {
+		builder.WriteString("\nRequest ID: " + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		builder.WriteString("\nResources:")
+		for _, resource := range m.Resources {
+			builder.WriteString("\n- " + resource)
+		}
+	}
+
+	return builder.String()
+}
+
+func validateSIWEURI(uri string) error {
+	if uri == "" {
+		return errors.New("value is required")
+	}
+	if strings.ContainsAny(uri, " \n\r") {
+		return errors.New("value must not contain whitespace")
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme == "" {
+		return errors.New("an absolute RFC 3986 URI is expected")
+	}
+	return nil
+}
@@ -0,0 +1,504 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// SLIP39SharesPrefix is the storage prefix of the shares awaiting delivery
+const SLIP39SharesPrefix = "slip39-shares/"
+
+// DefaultSLIP39IterationExponent matches the iteration exponent of Trezor
+const DefaultSLIP39IterationExponent = 1
+
+const (
+	slip39RadixBits       = 10
+	slip39ChecksumWords   = 3
+	slip39DigestLength    = 4
+	slip39DigestIndex     = 254
+	slip39SecretIndex     = 255
+	slip39RoundCount      = 4
+	slip39BaseIterations  = 10000
+	slip39MinSecretLength = 16
+	slip39MaxShareCount   = 16
+	slip39MinWords        = 20
+)
+
+// ErrInsufficientSLIP39Shares is returned when too few shares are combined to recover the secret
+var ErrInsufficientSLIP39Shares = errors.New("insufficient shares")
+
+// SLIP39Group is the member threshold and count of a share group
+type SLIP39Group struct {
+	Threshold int `json:"threshold"`
+	Count     int `json:"count"`
+}
+
+// ParseSLIP39Groups parses groups in the threshold/count format, e.g. 2/3
+func ParseSLIP39Groups(specs []string) ([]SLIP39Group, error) {
+	groups := make([]SLIP39Group, 0, len(specs))
+	for _, spec := range specs {
+		parts := strings.Split(strings.TrimSpace(spec), "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid group %s, expected threshold/count such as 2/3", spec)
+		}
+		threshold, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid group %s, expected threshold/count such as 2/3", spec)
+		}
+		count, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid group %s, expected threshold/count such as 2/3", spec)
+		}
+		groups = append(groups, SLIP39Group{Threshold: threshold, Count: count})
+	}
+	return groups, nil
+}
+
+// SLIP39Share is a decoded SLIP-39 mnemonic share
+type SLIP39Share struct {
+	Identifier        uint16
+	Extendable        bool
+	IterationExponent int
+	GroupIndex        int
+	GroupThreshold    int
+	GroupCount        int
+	MemberIndex       int
+	MemberThreshold   int
+	Value             []byte
+}
+
+// SplitSLIP39 encrypts the master secret with the passphrase and splits it
+// into the mnemonic shares of each group, any groupThreshold groups of which
+// recover the secret
+func SplitSLIP39(masterSecret []byte, passphrase string, groupThreshold int, groups []SLIP39Group, iterationExponent int) ([][]string, error) {
+	if len(masterSecret) < slip39MinSecretLength || len(masterSecret)%2 != 0 {
+		return nil, fmt.Errorf("the master secret must be an even number of bytes, at least %d", slip39MinSecretLength)
+	}
+	if err := validSLIP39Passphrase(passphrase); err != nil {
+		return nil, err
+	}
+	if iterationExponent < 0 || iterationExponent > 15 {
+		return nil, fmt.Errorf("invalid iteration exponent %d, must be between 0 and 15", iterationExponent)
+	}
+	if len(groups) == 0 || len(groups) > slip39MaxShareCount {
+		return nil, fmt.Errorf("the number of groups must be between 1 and %d", slip39MaxShareCount)
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) {
+		return nil, fmt.Errorf("invalid group threshold %d, must be between 1 and the number of groups %d", groupThreshold, len(groups))
+	}
+	for _, group := range groups {
+		if group.Threshold < 1 || group.Threshold > group.Count || group.Count > slip39MaxShareCount {
+			return nil, fmt.Errorf("invalid group %d/%d, the threshold must be between 1 and the count of at most %d", group.Threshold, group.Count, slip39MaxShareCount)
+		}
+		if group.Threshold == 1 && group.Count > 1 {
+			return nil, fmt.Errorf("invalid group 1/%d, use a 1/1 group instead of several shares with threshold 1", group.Count)
+		}
+	}
+
+	var identifierBytes [2]byte
+	if _, err := rand.Read(identifierBytes[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(identifierBytes[:]) & 0x7fff
+
+	encryptedSecret := slip39Encrypt(masterSecret, passphrase, iterationExponent, identifier, false)
+
+	groupShares, err := slip39SplitSecret(groupThreshold, len(groups), encryptedSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonics := make([][]string, len(groups))
+	for groupIndex, group := range groups {
+		memberShares, err := slip39SplitSecret(group.Threshold, group.Count, groupShares[groupIndex].value)
+		if err != nil {
+			return nil, err
+		}
+		for _, memberShare := range memberShares {
+			share := &SLIP39Share{
+				Identifier:        identifier,
+				IterationExponent: iterationExponent,
+				GroupIndex:        groupIndex,
+				GroupThreshold:    groupThreshold,
+				GroupCount:        len(groups),
+				MemberIndex:       int(memberShare.x),
+				MemberThreshold:   group.Threshold,
+				Value:             memberShare.value,
+			}
+			mnemonics[groupIndex] = append(mnemonics[groupIndex], share.Mnemonic())
+		}
+	}
+
+	return mnemonics, nil
+}
+
+// CombineSLIP39 recovers the master secret from the mnemonic shares
+func CombineSLIP39(mnemonics []string, passphrase string) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, errors.New("no shares provided")
+	}
+	if err := validSLIP39Passphrase(passphrase); err != nil {
+		return nil, err
+	}
+
+	var first *SLIP39Share
+	groups := map[int]map[int]*SLIP39Share{}
+	for _, mnemonic := range mnemonics {
+		share, err := DecodeSLIP39Share(mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = share
+		}
+		if share.Identifier != first.Identifier || share.Extendable != first.Extendable || share.IterationExponent != first.IterationExponent {
+			return nil, errors.New("the shares belong to different secrets")
+		}
+		if share.GroupThreshold != first.GroupThreshold || share.GroupCount != first.GroupCount {
+			return nil, errors.New("the shares have mismatching group parameters")
+		}
+
+		members, ok := groups[share.GroupIndex]
+		if !ok {
+			members = map[int]*SLIP39Share{}
+			groups[share.GroupIndex] = members
+		}
+		for _, member := range members {
+			if member.MemberThreshold != share.MemberThreshold {
+				return nil, fmt.Errorf("the shares of group %d have mismatching member thresholds", share.GroupIndex+1)
+			}
+			break
+		}
+		if member, ok := members[share.MemberIndex]; ok && string(member.Value) != string(share.Value) {
+			return nil, fmt.Errorf("conflicting shares for member %d of group %d", share.MemberIndex+1, share.GroupIndex+1)
+		}
+		members[share.MemberIndex] = share
+	}
+
+	var groupShares []slip39Point
+	for groupIndex := 0; groupIndex < first.GroupCount && len(groupShares) < first.GroupThreshold; groupIndex++ {
+		members := groups[groupIndex]
+		if len(members) == 0 {
+			continue
+		}
+
+		var memberShares []slip39Point
+		threshold := 0
+		for _, member := range members {
+			threshold = member.MemberThreshold
+			memberShares = append(memberShares, slip39Point{x: byte(member.MemberIndex), value: member.Value})
+		}
+		if len(memberShares) < threshold {
+			continue
+		}
+
+		groupSecret, err := slip39RecoverSecret(threshold, memberShares[:threshold])
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %v", groupIndex+1, err)
+		}
+		groupShares = append(groupShares, slip39Point{x: byte(groupIndex), value: groupSecret})
+	}
+	if len(groupShares) < first.GroupThreshold {
+		return nil, fmt.Errorf("%w, %d complete groups are required but %d were provided", ErrInsufficientSLIP39Shares, first.GroupThreshold, len(groupShares))
+	}
+
+	encryptedSecret, err := slip39RecoverSecret(first.GroupThreshold, groupShares)
+	if err != nil {
+		return nil, err
+	}
+
+	return slip39Decrypt(encryptedSecret, passphrase, first.IterationExponent, first.Identifier, first.Extendable), nil
+}
+
+// DecodeSLIP39Share parses and verifies the checksum of a mnemonic share
+func DecodeSLIP39Share(mnemonic string) (*SLIP39Share, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) < slip39MinWords {
+		return nil, fmt.Errorf("invalid share, a share has at least %d words", slip39MinWords)
+	}
+
+	indices := make([]int, len(words))
+	for i, word := range words {
+		index, ok := slip39WordIndex[word]
+		if !ok {
+			return nil, fmt.Errorf("invalid share, %s is not a SLIP-39 word", word)
+		}
+		indices[i] = index
+	}
+
+	valueWords := len(indices) - 4 - slip39ChecksumWords
+	paddingBits := valueWords * slip39RadixBits % 16
+	if paddingBits > 8 {
+		return nil, errors.New("invalid share length")
+	}
+
+	header := indices[0]<<10 | indices[1]
+	extendable := header>>4&1 == 1
+	if slip39Checksum(slip39Customization(extendable), indices) != 1 {
+		return nil, errors.New("invalid share checksum")
+	}
+
+	params := indices[2]<<10 | indices[3]
+	share := &SLIP39Share{
+		Identifier:        uint16(header >> 5),
+		Extendable:        extendable,
+		IterationExponent: header & 0xf,
+		GroupIndex:        params >> 16,
+		GroupThreshold:    params>>12&0xf + 1,
+		GroupCount:        params>>8&0xf + 1,
+		MemberIndex:       params >> 4 & 0xf,
+		MemberThreshold:   params&0xf + 1,
+	}
+	if share.GroupThreshold > share.GroupCount {
+		return nil, errors.New("invalid share, the group threshold exceeds the group count")
+	}
+
+	value := new(big.Int)
+	for _, index := range indices[4 : len(indices)-slip39ChecksumWords] {
+		value.Lsh(value, slip39RadixBits)
+		value.Or(value, big.NewInt(int64(index)))
+	}
+	valueLength := (valueWords*slip39RadixBits - paddingBits) / 8
+	if value.BitLen() > valueLength*8 {
+		return nil, errors.New("invalid share padding")
+	}
+	share.Value = make([]byte, valueLength)
+	valueBytes := value.Bytes()
+	copy(share.Value[valueLength-len(valueBytes):], valueBytes)
+
+	return share, nil
+}
+
+// Mnemonic encodes the share as SLIP-39 words
+func (s *SLIP39Share) Mnemonic() string {
+	extendable := 0
+	if s.Extendable {
+		extendable = 1
+	}
+	header := int(s.Identifier)<<5 | extendable<<4 | s.IterationExponent
+	params := s.GroupIndex<<16 | (s.GroupThreshold-1)<<12 | (s.GroupCount-1)<<8 | s.MemberIndex<<4 | (s.MemberThreshold - 1)
+	indices := []int{header >> 10, header & 0x3ff, params >> 10, params & 0x3ff}
+
+	valueWords := (len(s.Value)*8 + slip39RadixBits - 1) / slip39RadixBits
+	value := new(big.Int).SetBytes(s.Value)
+	for i := valueWords - 1; i >= 0; i-- {
+		word := new(big.Int).Rsh(value, uint(i*slip39RadixBits))
+		indices = append(indices, int(word.Int64()&0x3ff))
+	}
+
+	checksum := slip39Checksum(slip39Customization(s.Extendable), append(indices, 0, 0, 0)) ^ 1
+	for i := slip39ChecksumWords - 1; i >= 0; i-- {
+		indices = append(indices, checksum>>(uint(i)*slip39RadixBits)&0x3ff)
+	}
+
+	words := make([]string, len(indices))
+	for i, index := range indices {
+		words[i] = slip39Wordlist[index]
+	}
+	return strings.Join(words, " ")
+}
+
+var slip39WordIndex = func() map[string]int {
+	index := make(map[string]int, len(slip39Wordlist))
+	for i, word := range slip39Wordlist {
+		index[word] = i
+	}
+	return index
+}()
+
+func validSLIP39Passphrase(passphrase string) error {
+	for _, c := range passphrase {
+		if c < 32 || c > 126 {
+			return errors.New("the share passphrase must only contain printable ASCII characters")
+		}
+	}
+	return nil
+}
+
+func slip39Customization(extendable bool) string {
+	if extendable {
+		return "shamir_extendable"
+	}
+	return "shamir"
+}
+
+// slip39Checksum is the RS1024 checksum of the customization string and the word indices
+func slip39Checksum(customization string, indices []int) int {
+	generator := [10]int{0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009, 0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120}
+
+	checksum := 1
+	values := make([]int, 0, len(customization)+len(indices))
+	for _, c := range []byte(customization) {
+		values = append(values, int(c))
+	}
+	for _, value := range append(values, indices...) {
+		top := checksum >> 20
+		checksum = (checksum&0xfffff)<<10 ^ value
+		for i := 0; i < 10; i++ {
+			if top>>uint(i)&1 == 1 {
+				checksum ^= generator[i]
+			}
+		}
+	}
+	return checksum
+}
+
+// slip39Encrypt encrypts the master secret with the four rounds Feistel
+// network of SLIP-39
+func slip39Encrypt(masterSecret []byte, passphrase string, iterationExponent int, identifier uint16, extendable bool) []byte {
+	left, right := masterSecret[:len(masterSecret)/2], masterSecret[len(masterSecret)/2:]
+	for round := 0; round < slip39RoundCount; round++ {
+		left, right = right, slip39Round(round, passphrase, iterationExponent, identifier, extendable, left, right)
+	}
+	return append(append([]byte{}, right...), left...)
+}
+
+func slip39Decrypt(encryptedSecret []byte, passphrase string, iterationExponent int, identifier uint16, extendable bool) []byte {
+	left, right := encryptedSecret[:len(encryptedSecret)/2], encryptedSecret[len(encryptedSecret)/2:]
+	for round := slip39RoundCount - 1; round >= 0; round-- {
+		left, right = right, slip39Round(round, passphrase, iterationExponent, identifier, extendable, left, right)
+	}
+	return append(append([]byte{}, right...), left...)
+}
+
+// slip39Round returns left xor F(round, right)
+func slip39Round(round int, passphrase string, iterationExponent int, identifier uint16, extendable bool, left []byte, right []byte) []byte {
+	var salt []byte
+	if !extendable {
+		salt = append([]byte("shamir"), byte(identifier>>8), byte(identifier))
+	}
+	salt = append(salt, right...)
+
+	password := append([]byte{byte(round)}, passphrase...)
+	iterations := (slip39BaseIterations << uint(iterationExponent)) / slip39RoundCount
+	key := pbkdf2.Key(password, salt, iterations, len(right), sha256.New)
+
+	result := make([]byte, len(left))
+	for i := range left {
+		result[i] = left[i] ^ key[i]
+	}
+	return result
+}
+
+type slip39Point struct {
+	x     byte
+	value []byte
+}
+
+// slip39SplitSecret splits the secret into count shares with the threshold,
+// embedding a digest of the secret to detect wrong recoveries
+func slip39SplitSecret(threshold int, count int, secret []byte) ([]slip39Point, error) {
+	shares := make([]slip39Point, 0, count)
+	if threshold == 1 {
+		for x := 0; x < count; x++ {
+			shares = append(shares, slip39Point{x: byte(x), value: secret})
+		}
+		return shares, nil
+	}
+
+	for x := 0; x < threshold-2; x++ {
+		value := make([]byte, len(secret))
+		if _, err := rand.Read(value); err != nil {
+			return nil, err
+		}
+		shares = append(shares, slip39Point{x: byte(x), value: value})
+	}
+
+	randomPart := make([]byte, len(secret)-slip39DigestLength)
+	if _, err := rand.Read(randomPart); err != nil {
+		return nil, err
+	}
+	digest := append(slip39Digest(randomPart, secret), randomPart...)
+
+	basePoints := append(append([]slip39Point{}, shares...),
+		slip39Point{x: slip39DigestIndex, value: digest},
+		slip39Point{x: slip39SecretIndex, value: secret},
+	)
+	for x := threshold - 2; x < count; x++ {
+		shares = append(shares, slip39Point{x: byte(x), value: slip39Interpolate(basePoints, byte(x))})
+	}
+
+	return shares, nil
+}
+
+func slip39RecoverSecret(threshold int, shares []slip39Point) ([]byte, error) {
+	if threshold == 1 {
+		return shares[0].value, nil
+	}
+
+	secret := slip39Interpolate(shares, slip39SecretIndex)
+	digest := slip39Interpolate(shares, slip39DigestIndex)
+	if !hmac.Equal(digest[:slip39DigestLength], slip39Digest(digest[slip39DigestLength:], secret)) {
+		return nil, errors.New("invalid digest of the shared secret")
+	}
+	return secret, nil
+}
+
+func slip39Digest(randomPart []byte, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomPart)
+	mac.Write(secret)
+	return mac.Sum(nil)[:slip39DigestLength]
+}
+
+// slip39Interpolate evaluates at x the polynomials over GF(256) passing through the points
+func slip39Interpolate(points []slip39Point, x byte) []byte {
+	for _, point := range points {
+		if point.x == x {
+			return append([]byte{}, point.value...)
+		}
+	}
+
+	result := make([]byte, len(points[0].value))
+	for i, point := range points {
+		basis := byte(1)
+		for j, other := range points {
+			if i != j {
+				basis = gf256Mul(basis, gf256Div(x^other.x, point.x^other.x))
+			}
+		}
+		for k, value := range point.value {
+			result[k] ^= gf256Mul(basis, value)
+		}
+	}
+	return result
+}
+
+// gf256Exp and gf256Log tabulate the powers of the generator 3 of GF(256)
+// with the Rijndael polynomial
+var gf256Exp, gf256Log = func() ([255]byte, [256]int) {
+	var exp [255]byte
+	var log [256]int
+	value := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(value)
+		log[value] = i
+		value ^= value << 1
+		if value&0x100 != 0 {
+			value ^= 0x11b
+		}
+	}
+	return exp, log
+}()
+
+func gf256Mul(a byte, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gf256Exp[(gf256Log[a]+gf256Log[b])%255]
+}
+
+func gf256Div(a byte, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gf256Exp[(gf256Log[a]-gf256Log[b]+255)%255]
+}
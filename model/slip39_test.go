@@ -0,0 +1,86 @@
+package model
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// vectors 1 and 4 of the SLIP-39 reference implementation, python-shamir-mnemonic
+func TestCombineSLIP39(t *testing.T) {
+	vectors := []struct {
+		name         string
+		mnemonics    []string
+		masterSecret string
+	}{
+		{
+			"valid mnemonic without sharing (128 bits)",
+			[]string{
+				"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard",
+			},
+			"bb54aac4b89dc868ba37d9cc21b2cece",
+		},
+		{
+			"basic sharing 2-of-3 (128 bits)",
+			[]string{
+				"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+				"shadow pistol academic acid actress prayer class unknown daughter sweater depict flip twice unkind craft early superior advocate guest smoking",
+			},
+			"b43ceb7e57a0ea8766221624d01b0864",
+		},
+	}
+
+	for _, vector := range vectors {
+		masterSecret, err := CombineSLIP39(vector.mnemonics, "TREZOR")
+		if err != nil {
+			t.Fatalf("%s: %v", vector.name, err)
+		}
+		if hex.EncodeToString(masterSecret) != vector.masterSecret {
+			t.Errorf("%s: got master secret %x, want %s", vector.name, masterSecret, vector.masterSecret)
+		}
+
+		for _, mnemonic := range vector.mnemonics {
+			share, err := DecodeSLIP39Share(mnemonic)
+			if err != nil {
+				t.Fatalf("%s: %v", vector.name, err)
+			}
+			if share.Mnemonic() != mnemonic {
+				t.Errorf("%s: share re-encodes as %s", vector.name, share.Mnemonic())
+			}
+		}
+	}
+}
+
+// a share of vector 4 is below the member threshold of its group
+func TestCombineSLIP39BelowThreshold(t *testing.T) {
+	_, err := CombineSLIP39([]string{
+		"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+	}, "TREZOR")
+	if err == nil {
+		t.Fatal("a single share of a 2-of-3 group recovered a secret")
+	}
+}
+
+// shares split by the plugin are recovered by any threshold of them
+func TestSplitSLIP39(t *testing.T) {
+	masterSecret, _ := hex.DecodeString("b43ceb7e57a0ea8766221624d01b0864")
+	groups, err := ParseSLIP39Groups([]string{"2/3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shares, err := SplitSLIP39(masterSecret, "TREZOR", 1, groups, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != 1 || len(shares[0]) != 3 {
+		t.Fatalf("got %d groups, want 1 group of 3 shares", len(shares))
+	}
+
+	recovered, err := CombineSLIP39([]string{shares[0][2], shares[0][0]}, "TREZOR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(recovered) != hex.EncodeToString(masterSecret) {
+		t.Errorf("got master secret %x, want %x", recovered, masterSecret)
+	}
+}
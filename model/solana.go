@@ -0,0 +1,231 @@
+package model
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// ChainSolana is the chain of accounts holding SLIP-10 ed25519 keys
+const ChainSolana = "solana"
+
+// SolanaDerivationPath returns the derivation path of the Solana account at the index,
+// the layout used by Phantom and the Solana CLI
+func SolanaDerivationPath(index uint32) string {
+	return fmt.Sprintf("m/44'/501'/%d'/0'", index)
+}
+
+// DeriveEd25519Key derives the SLIP-10 ed25519 key of the path from the seed.
+// SLIP-10 only defines hardened derivation for ed25519.
+func DeriveEd25519Key(seed []byte, path accounts.DerivationPath) (ed25519.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	for _, index := range path {
+		if index < 0x80000000 {
+			return nil, errors.New("ed25519 derivation paths must be hardened")
+		}
+
+		data := make([]byte, 37)
+		copy(data[1:33], key)
+		binary.BigEndian.PutUint32(data[33:], index)
+
+		mac = hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum = mac.Sum(nil)
+		key, chainCode = sum[:32], sum[32:]
+	}
+
+	return ed25519.NewKeyFromSeed(key), nil
+}
+
+// DeriveSolana derives a Solana account, whose address is the base58 public key
+func (w *Wallet) DeriveSolana(path accounts.DerivationPath) (*Account, error) {
+	if !w.HasSeed() {
+		return nil, ErrWalletWithoutSeed
+	}
+
+	seed, err := hex.DecodeString(w.Seed)
+	if err != nil {
+		return nil, errors.New("Fail to decode seed")
+	}
+
+	privateKey, err := DeriveEd25519Key(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+
+	URL := accounts.URL{
+		Scheme: "",
+		Path:   path.String(),
+	}
+
+	return &Account{
+		Address:    base58.Encode(publicKey),
+		URL:        URL.String(),
+		PrivateKey: hex.EncodeToString(privateKey.Seed()),
+		PublicKey:  hex.EncodeToString(publicKey),
+		Chain:      ChainSolana,
+	}, nil
+}
+
+// SolanaMessage is the part of a serialized legacy or v0 Solana message the signer needs
+type SolanaMessage struct {
+	Version              int // -1 for legacy messages
+	NumRequiredSignature int
+	AccountKeys          [][]byte
+	RecentBlockhash      []byte
+	NumInstructions      int
+}
+
+// ParseSolanaMessage parses a serialized legacy or versioned message, checking
+// it is well formed up to its last byte
+func ParseSolanaMessage(raw []byte) (*SolanaMessage, error) {
+	r := &solanaReader{data: raw}
+	message := &SolanaMessage{Version: -1}
+
+	prefix := r.readByte()
+	if prefix&0x80 != 0 {
+		message.Version = int(prefix & 0x7f)
+		if message.Version != 0 {
+			return nil, fmt.Errorf("unsupported solana message version %d", message.Version)
+		}
+		prefix = r.readByte()
+	}
+	message.NumRequiredSignature = int(prefix)
+	numReadonlySigned := int(r.readByte())
+	r.readByte() // readonly unsigned accounts
+
+	numKeys := r.readCompactU16()
+	for i := 0; i < numKeys && r.err == nil; i++ {
+		message.AccountKeys = append(message.AccountKeys, r.readBytes(32))
+	}
+	message.RecentBlockhash = r.readBytes(32)
+
+	message.NumInstructions = r.readCompactU16()
+	for i := 0; i < message.NumInstructions && r.err == nil; i++ {
+		r.readByte() // program ID index
+		r.readBytes(r.readCompactU16())
+		r.readBytes(r.readCompactU16())
+	}
+
+	if message.Version == 0 {
+		numLookups := r.readCompactU16()
+		for i := 0; i < numLookups && r.err == nil; i++ {
+			r.readBytes(32)
+			r.readBytes(r.readCompactU16())
+			r.readBytes(r.readCompactU16())
+		}
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.offset != len(raw) {
+		return nil, errors.New("solana message has trailing data")
+	}
+	if message.NumRequiredSignature == 0 || message.NumRequiredSignature > numKeys || numReadonlySigned >= message.NumRequiredSignature {
+		return nil, errors.New("invalid solana message header")
+	}
+
+	return message, nil
+}
+
+// SignerIndex returns the signature slot of the public key, -1 if it is not a required signer
+func (m *SolanaMessage) SignerIndex(publicKey []byte) int {
+	for i := 0; i < m.NumRequiredSignature; i++ {
+		if string(m.AccountKeys[i]) == string(publicKey) {
+			return i
+		}
+	}
+	return -1
+}
+
+// SolanaTransaction returns the wire format of the transaction, the compact
+// array of signatures followed by the message
+func SolanaTransaction(signatures [][]byte, message []byte) []byte {
+	transaction := encodeCompactU16(len(signatures))
+	for _, signature := range signatures {
+		transaction = append(transaction, signature...)
+	}
+	return append(transaction, message...)
+}
+
+// ParseSolanaTransaction splits a wire format transaction into its signatures and message
+func ParseSolanaTransaction(raw []byte) ([][]byte, []byte, error) {
+	r := &solanaReader{data: raw}
+	signatures := [][]byte{}
+	numSignatures := r.readCompactU16()
+	for i := 0; i < numSignatures && r.err == nil; i++ {
+		signatures = append(signatures, r.readBytes(ed25519.SignatureSize))
+	}
+	if r.err != nil {
+		return nil, nil, r.err
+	}
+	return signatures, raw[r.offset:], nil
+}
+
+func encodeCompactU16(value int) []byte {
+	encoded := []byte{}
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if value == 0 {
+			return append(encoded, b)
+		}
+		encoded = append(encoded, b|0x80)
+	}
+}
+
+// solanaReader reads a serialized message, remembering the first error
+type solanaReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (r *solanaReader) readBytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.offset+n > len(r.data) {
+		r.err = errors.New("solana message is truncated")
+		return nil
+	}
+	value := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return value
+}
+
+func (r *solanaReader) readByte() byte {
+	value := r.readBytes(1)
+	if value == nil {
+		return 0
+	}
+	return value[0]
+}
+
+func (r *solanaReader) readCompactU16() int {
+	value := 0
+	for i := 0; i < 3; i++ {
+		b := r.readByte()
+		value |= int(b&0x7f) << uint(7*i)
+		if b&0x80 == 0 {
+			return value
+		}
+	}
+	if r.err == nil {
+		r.err = errors.New("invalid solana compact-u16")
+	}
+	return 0
+}
@@ -0,0 +1,163 @@
+package model
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// test vector 1 for ed25519 of SLIP-10, public keys carry the 0x00 prefix of the spec
+func TestDeriveEd25519Key(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	vectors := []struct {
+		path       string
+		privateKey string
+		publicKey  string
+	}{
+		{"m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", "00a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed"},
+		{"m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3", "008c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c"},
+		{"m/0'/1'", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2", "001932a5270f335bed617d5b935c80aedb1a35bd9fc1e31acafd5372c30f5c1187"},
+		{"m/0'/1'/2'", "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9", "00ae98736566d30ed0e9d2f4486a64bc95740d89c7db33f52121f8ea8f76ff0fc1"},
+		{"m/0'/1'/2'/2'", "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662", "008abae2d66361c879b900d204ad2cc4984fa2aa344dd7ddc46007329ac76c429c"},
+		{"m/0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793", "003c24da049451555d51a7014a37337aa4e12d41e485abccfa46b47dfb2af54b7a"},
+	}
+
+	for _, vector := range vectors {
+		path := accounts.DerivationPath{}
+		if vector.path != "m" {
+			path = MustParseDerivationPath(vector.path)
+		}
+		privateKey, err := DeriveEd25519Key(seed, path)
+		if err != nil {
+			t.Fatalf("%s: %v", vector.path, err)
+		}
+		if hex.EncodeToString(privateKey.Seed()) != vector.privateKey {
+			t.Errorf("%s: got private key %x, want %s", vector.path, privateKey.Seed(), vector.privateKey)
+		}
+		publicKey := "00" + hex.EncodeToString(privateKey.Public().(ed25519.PublicKey))
+		if publicKey != vector.publicKey {
+			t.Errorf("%s: got public key %s, want %s", vector.path, publicKey, vector.publicKey)
+		}
+	}
+}
+
+func TestDeriveEd25519KeyRefusesNormalIndexes(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	_, err := DeriveEd25519Key(seed, MustParseDerivationPath("m/0'/1"))
+	if err == nil {
+		t.Fatal("a non-hardened index was derived")
+	}
+}
+
+// newTestSolanaMessage returns a transfer message with the fee payer and the
+// second signer, as a legacy message or a v0 message with an address lookup table
+func newTestSolanaMessage(feePayer, signer []byte, versioned bool) []byte {
+	message := []byte{}
+	if versioned {
+		message = append(message, 0x80)
+	}
+	message = append(message, 2, 0, 1) // 2 signers, the system program is readonly
+	message = append(message, 3)
+	message = append(message, feePayer...)
+	message = append(message, signer...)
+	message = append(message, make([]byte, 32)...)                             // system program
+	message = append(message, bytes.Repeat([]byte{0x11}, 32)...)               // recent blockhash
+	message = append(message, 1)                                               // 1 instruction
+	message = append(message, 2, 2, 1, 3)                                      // system program, signer and lookup account
+	message = append(message, 12, 2, 0, 0, 0, 0x40, 0x42, 0x0f, 0, 0, 0, 0, 0) // transfer of 1000000 lamports
+	if versioned {
+		message = append(message, 1)
+		message = append(message, bytes.Repeat([]byte{0x22}, 32)...) // lookup table
+		message = append(message, 1, 0)                              // writable index 0
+		message = append(message, 0)                                 // no readonly index
+	}
+	return message
+}
+
+func TestSolanaMessageRoundTrip(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	feePayer, err := wallet.DeriveSolana(MustParseDerivationPath(SolanaDerivationPath(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := wallet.DeriveSolana(MustParseDerivationPath(SolanaDerivationPath(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	feePayerKey := ed25519.NewKeyFromSeed(mustDecodeHex(t, feePayer.PrivateKey))
+	signerKey := ed25519.NewKeyFromSeed(mustDecodeHex(t, signer.PrivateKey))
+	feePayerPublicKey := feePayerKey.Public().(ed25519.PublicKey)
+	signerPublicKey := signerKey.Public().(ed25519.PublicKey)
+
+	for _, versioned := range []bool{false, true} {
+		rawMessage := newTestSolanaMessage(feePayerPublicKey, signerPublicKey, versioned)
+
+		message, err := ParseSolanaMessage(rawMessage)
+		if err != nil {
+			t.Fatalf("versioned %v: %v", versioned, err)
+		}
+		wantVersion := -1
+		if versioned {
+			wantVersion = 0
+		}
+		if message.Version != wantVersion || message.NumRequiredSignature != 2 || message.NumInstructions != 1 {
+			t.Errorf("versioned %v: got version %d with %d signers and %d instructions", versioned, message.Version, message.NumRequiredSignature, message.NumInstructions)
+		}
+		if message.SignerIndex(feePayerPublicKey) != 0 || message.SignerIndex(signerPublicKey) != 1 {
+			t.Errorf("versioned %v: got signer indexes %d and %d", versioned, message.SignerIndex(feePayerPublicKey), message.SignerIndex(signerPublicKey))
+		}
+		if message.SignerIndex(make([]byte, 32)) != -1 {
+			t.Errorf("versioned %v: the readonly system program is a signer", versioned)
+		}
+
+		// the second signer signs first, then the fee payer completes the transaction
+		signatures := [][]byte{make([]byte, ed25519.SignatureSize), ed25519.Sign(signerKey, rawMessage)}
+		signatures, parsedMessage, err := ParseSolanaTransaction(SolanaTransaction(signatures, rawMessage))
+		if err != nil {
+			t.Fatalf("versioned %v: %v", versioned, err)
+		}
+		if !bytes.Equal(parsedMessage, rawMessage) || len(signatures) != 2 {
+			t.Fatalf("versioned %v: the transaction does not split into its signatures and message", versioned)
+		}
+		signatures[0] = ed25519.Sign(feePayerKey, parsedMessage)
+
+		signatures, parsedMessage, err = ParseSolanaTransaction(SolanaTransaction(signatures, parsedMessage))
+		if err != nil {
+			t.Fatalf("versioned %v: %v", versioned, err)
+		}
+		if !ed25519.Verify(feePayerPublicKey, parsedMessage, signatures[0]) || !ed25519.Verify(signerPublicKey, parsedMessage, signatures[1]) {
+			t.Errorf("versioned %v: the signatures do not verify", versioned)
+		}
+	}
+}
+
+func TestParseSolanaMessageRefusesMalformedMessages(t *testing.T) {
+	feePayer := bytes.Repeat([]byte{0x01}, 32)
+	signer := bytes.Repeat([]byte{0x02}, 32)
+
+	for _, versioned := range []bool{false, true} {
+		rawMessage := newTestSolanaMessage(feePayer, signer, versioned)
+
+		_, err := ParseSolanaMessage(rawMessage[:len(rawMessage)-1])
+		if err == nil {
+			t.Errorf("versioned %v: a truncated message was parsed", versioned)
+		}
+		_, err = ParseSolanaMessage(append(append([]byte{}, rawMessage...), 0))
+		if err == nil {
+			t.Errorf("versioned %v: a message with trailing data was parsed", versioned)
+		}
+	}
+
+	rawMessage := newTestSolanaMessage(feePayer, signer, true)
+	rawMessage[0] = 0x81
+	_, err := ParseSolanaMessage(rawMessage)
+	if err == nil {
+		t.Error("a v1 message was parsed")
+	}
+}
@@ -0,0 +1,206 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TaggedHash returns the BIP-340 tagged hash of the messages
+func TaggedHash(tag string, messages ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, message := range messages {
+		h.Write(message)
+	}
+	return h.Sum(nil)
+}
+
+// XOnlyPublicKey returns the 32 bytes x coordinate of the public key
+func XOnlyPublicKey(publicKey *btcec.PublicKey) []byte {
+	return common.LeftPadBytes(publicKey.X.Bytes(), 32)
+}
+
+// taprootTweak returns the BIP-341 tweak of the internal key committing to
+// the merkle root of the script tree, nil for a key-path-only output
+func taprootTweak(internalX []byte, merkleRoot []byte) (*big.Int, error) {
+	tweak := new(big.Int).SetBytes(TaggedHash("TapTweak", internalX, merkleRoot))
+	if tweak.Cmp(btcec.S256().N) >= 0 {
+		return nil, errors.New("taproot tweak is out of range")
+	}
+	return tweak, nil
+}
+
+// TaprootOutputKey returns the x-only output key of a taproot output, i.e. the
+// internal key tweaked with the merkle root, nil for a BIP-86 key-path-only output.
+func TaprootOutputKey(internalKey *btcec.PublicKey, merkleRoot []byte) ([]byte, error) {
+	curve := btcec.S256()
+
+	tweak, err := taprootTweak(XOnlyPublicKey(internalKey), merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// lift_x picks the point with the even y coordinate
+	y := new(big.Int).Set(internalKey.Y)
+	if y.Bit(0) == 1 {
+		y.Sub(curve.P, y)
+	}
+
+	tweakX, tweakY := curve.ScalarBaseMult(tweak.Bytes())
+	outputX, outputY := curve.Add(internalKey.X, y, tweakX, tweakY)
+	if outputX.Sign() == 0 && outputY.Sign() == 0 {
+		return nil, errors.New("taproot output key is infinity")
+	}
+
+	return common.LeftPadBytes(outputX.Bytes(), 32), nil
+}
+
+// TaprootTweakPrivateKey returns the private key of the output key of the
+// internal private key, see TaprootOutputKey
+func TaprootTweakPrivateKey(privateKey *btcec.PrivateKey, merkleRoot []byte) (*btcec.PrivateKey, error) {
+	curve := btcec.S256()
+	publicKey := privateKey.PubKey()
+
+	d := new(big.Int).Set(privateKey.D)
+	if publicKey.Y.Bit(0) == 1 {
+		d.Sub(curve.N, d)
+	}
+
+	tweak, err := taprootTweak(XOnlyPublicKey(publicKey), merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Add(d, tweak)
+	d.Mod(d, curve.N)
+	if d.Sign() == 0 {
+		return nil, errors.New("tweaked private key is zero")
+	}
+
+	tweaked, _ := btcec.PrivKeyFromBytes(curve, common.LeftPadBytes(d.Bytes(), 32))
+	return tweaked, nil
+}
+
+// SchnorrSign returns the 64 bytes BIP-340 signature of the 32 bytes message
+func SchnorrSign(privateKey *btcec.PrivateKey, message []byte) ([]byte, error) {
+	if len(message) != 32 {
+		return nil, errors.New("schnorr message must be 32 bytes")
+	}
+
+	auxRand := make([]byte, 32)
+	if _, err := rand.Read(auxRand); err != nil {
+		return nil, err
+	}
+
+	return schnorrSign(privateKey, message, auxRand)
+}
+
+func schnorrSign(privateKey *btcec.PrivateKey, message []byte, auxRand []byte) ([]byte, error) {
+	curve := btcec.S256()
+	publicKey := privateKey.PubKey()
+	publicX := XOnlyPublicKey(publicKey)
+
+	d := new(big.Int).Set(privateKey.D)
+	if publicKey.Y.Bit(0) == 1 {
+		d.Sub(curve.N, d)
+	}
+
+	t := common.LeftPadBytes(d.Bytes(), 32)
+	aux := TaggedHash("BIP0340/aux", auxRand)
+	for i := range t {
+		t[i] ^= aux[i]
+	}
+
+	k := new(big.Int).SetBytes(TaggedHash("BIP0340/nonce", t, publicX, message))
+	k.Mod(k, curve.N)
+	if k.Sign() == 0 {
+		return nil, errors.New("schnorr nonce is zero")
+	}
+
+	rX, rY := curve.ScalarBaseMult(common.LeftPadBytes(k.Bytes(), 32))
+	if rY.Bit(0) == 1 {
+		k.Sub(curve.N, k)
+	}
+	r := common.LeftPadBytes(rX.Bytes(), 32)
+
+	e := new(big.Int).SetBytes(TaggedHash("BIP0340/challenge", r, publicX, message))
+	e.Mod(e, curve.N)
+
+	s := e.Mul(e, d)
+	s.Add(s, k)
+	s.Mod(s, curve.N)
+
+	signature := append(r, common.LeftPadBytes(s.Bytes(), 32)...)
+	if !SchnorrVerify(publicX, message, signature) {
+		return nil, errors.New("schnorr signature does not verify")
+	}
+	return signature, nil
+}
+
+// SchnorrVerify checks a BIP-340 signature of the message against the x-only public key
+func SchnorrVerify(publicX []byte, message []byte, signature []byte) bool {
+	if len(publicX) != 32 || len(signature) != 64 {
+		return false
+	}
+
+	curve := btcec.S256()
+	pX, pY, ok := liftX(publicX)
+	if !ok {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if r.Cmp(curve.P) >= 0 || s.Cmp(curve.N) >= 0 {
+		return false
+	}
+
+	e := new(big.Int).SetBytes(TaggedHash("BIP0340/challenge", signature[:32], publicX, message))
+	e.Mod(e, curve.N)
+
+	// R = s*G - e*P
+	sX, sY := curve.ScalarBaseMult(common.LeftPadBytes(s.Bytes(), 32))
+	eX, eY := curve.ScalarMult(pX, pY, common.LeftPadBytes(e.Bytes(), 32))
+	eY.Sub(curve.P, eY)
+	rX, rY := curve.Add(sX, sY, eX, eY)
+	if rX.Sign() == 0 && rY.Sign() == 0 {
+		return false
+	}
+
+	return rY.Bit(0) == 0 && rX.Cmp(r) == 0
+}
+
+// liftX returns the point with the x coordinate and an even y coordinate
+func liftX(x []byte) (*big.Int, *big.Int, bool) {
+	curve := btcec.S256()
+
+	pX := new(big.Int).SetBytes(x)
+	if pX.Cmp(curve.P) >= 0 {
+		return nil, nil, false
+	}
+
+	// y^2 = x^3 + 7
+	c := new(big.Int).Exp(pX, big.NewInt(3), curve.P)
+	c.Add(c, big.NewInt(7))
+	c.Mod(c, curve.P)
+
+	exponent := new(big.Int).Add(curve.P, big.NewInt(1))
+	exponent.Rsh(exponent, 2)
+	pY := new(big.Int).Exp(c, exponent, curve.P)
+	if new(big.Int).Exp(pY, big.NewInt(2), curve.P).Cmp(c) != 0 {
+		return nil, nil, false
+	}
+
+	if pY.Bit(0) == 1 {
+		pY.Sub(curve.P, pY)
+	}
+	return pX, pY, true
+}
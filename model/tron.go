@@ -0,0 +1,81 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ChainTron is the chain of TRON accounts
+const ChainTron = "tron"
+
+// DefaultTronDerivationPath is the path of the first TRON account
+const DefaultTronDerivationPath = "m/44'/195'/0'/0/0"
+
+// tronAddressPrefix prefixes the 20 bytes account ID of TRON mainnet addresses
+const tronAddressPrefix = 0x41
+
+// DeriveTron derives a TRON account, whose address is the base58check
+// encoding of the Ethereum address prefixed with 0x41
+func (w *Wallet) DeriveTron(path accounts.DerivationPath) (*Account, error) {
+	privateKey, err := w.derivePrivateKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	URL := accounts.URL{
+		Scheme: "",
+		Path:   path.String(),
+	}
+
+	return &Account{
+		Address:    base58.CheckEncode(crypto.PubkeyToAddress(privateKey.PublicKey).Bytes(), tronAddressPrefix),
+		URL:        URL.String(),
+		PrivateKey: privateKeyHex(privateKey),
+		PublicKey:  publicKeyHex(&privateKey.PublicKey),
+		Chain:      ChainTron,
+	}, nil
+}
+
+// TronHexAddress returns the hex form, 41 followed by the account ID, of a base58 TRON address
+func TronHexAddress(address string) (string, error) {
+	accountID, version, err := base58.CheckDecode(address)
+	if err != nil || version != tronAddressPrefix || len(accountID) != 20 {
+		return "", fmt.Errorf("invalid TRON address %s", address)
+	}
+	return hex.EncodeToString(append([]byte{tronAddressPrefix}, accountID...)), nil
+}
+
+// TronTransactionID returns the ID of a TRON transaction, the SHA-256 of its raw_data
+func TronTransactionID(rawData []byte) []byte {
+	hash := sha256.Sum256(rawData)
+	return hash[:]
+}
+
+// TronSignedTransaction returns the protobuf Transaction made of the raw_data and its signature
+func TronSignedTransaction(rawData []byte, signature []byte) ([]byte, error) {
+	if len(signature) != crypto.SignatureLength {
+		return nil, errors.New("invalid TRON signature")
+	}
+
+	// field 1 raw_data and field 2 signature, both length delimited
+	transaction := append([]byte{0x0a}, protoVarint(uint64(len(rawData)))...)
+	transaction = append(transaction, rawData...)
+	transaction = append(transaction, 0x12)
+	transaction = append(transaction, protoVarint(uint64(len(signature)))...)
+	return append(transaction, signature...), nil
+}
+
+func protoVarint(value uint64) []byte {
+	encoded := []byte{}
+	for value >= 0x80 {
+		encoded = append(encoded, byte(value)|0x80)
+		value >>= 7
+	}
+	return append(encoded, byte(value))
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// the first TRON account of the "abandon ... about" mnemonic, as TronLink derives it
+func TestDeriveTron(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := wallet.DeriveTron(MustParseDerivationPath(DefaultTronDerivationPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Address != "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH" {
+		t.Errorf("got address %s, want TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", account.Address)
+	}
+
+	hexAddress, err := TronHexAddress(account.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, err := crypto.HexToECDSA(account.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hexAddress != "41"+hex.EncodeToString(crypto.PubkeyToAddress(privateKey.PublicKey).Bytes()) {
+		t.Errorf("got hex address %s for the account ID %x", hexAddress, crypto.PubkeyToAddress(privateKey.PublicKey).Bytes())
+	}
+
+	_, err = TronHexAddress("1BoatSLRHtKNngkdXEeobR76b53LETtpyT")
+	if err == nil {
+		t.Error("a bitcoin address was accepted as a TRON address")
+	}
+}
+
+// the signer recovered from the signature of the signed transaction is the account
+func TestTronSignedTransaction(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := wallet.DeriveTron(MustParseDerivationPath(DefaultTronDerivationPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, err := crypto.HexToECDSA(account.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// longer than 127 bytes, so its length takes 2 varint bytes
+	rawData := bytes.Repeat([]byte{0x5a}, 200)
+	signature, err := crypto.Sign(TronTransactionID(rawData), privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transaction, err := TronSignedTransaction(rawData, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := bytes.NewReader(transaction)
+	fields := map[byte][]byte{}
+	for r.Len() > 0 {
+		tag, _ := r.ReadByte()
+		length, err := binary.ReadUvarint(r)
+		if err != nil || length > uint64(r.Len()) {
+			t.Fatalf("invalid transaction %x", transaction)
+		}
+		value := make([]byte, length)
+		r.Read(value)
+		fields[tag] = value
+	}
+	if !bytes.Equal(fields[0x0a], rawData) {
+		t.Fatalf("got raw_data %x", fields[0x0a])
+	}
+
+	publicKey, err := crypto.SigToPub(TronTransactionID(fields[0x0a]), fields[0x12])
+	if err != nil {
+		t.Fatal(err)
+	}
+	hexAddress, err := TronHexAddress(account.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer := "41" + hex.EncodeToString(crypto.PubkeyToAddress(*publicKey).Bytes()); signer != hexAddress {
+		t.Errorf("got signer %s, want %s", signer, hexAddress)
+	}
+
+	_, err = TronSignedTransaction(rawData, signature[:64])
+	if err == nil {
+		t.Error("a signature without recovery ID was accepted")
+	}
+}
@@ -0,0 +1,157 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EntryPoint versions of the supported UserOperation layouts
+const (
+	EntryPointV06 = "0.6"
+	EntryPointV07 = "0.7"
+)
+
+// AccountExecuteABI is the execute function of the smart accounts derived from
+// the reference SimpleAccount, which most user operations call
+const AccountExecuteABI = `[
+	{"type":"function","name":"execute","inputs":[{"name":"dest","type":"address"},{"name":"value","type":"uint256"},{"name":"func","type":"bytes"}],"outputs":[]}
+]`
+
+var accountExecuteABI abi.ABI
+
+func init() {
+	var err error
+	accountExecuteABI, err = abi.JSON(strings.NewReader(AccountExecuteABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// UserOperation is an ERC-4337 user operation.
+// Gas fields are kept unpacked; the v0.7 layout packs them when hashing.
+type UserOperation struct {
+	Version              string
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+}
+
+// Execution decodes the call data as execute(address,uint256,bytes) and returns
+// the destination, value and calldata of the call the sender makes
+func (op *UserOperation) Execution() (common.Address, *big.Int, []byte, bool) {
+	if len(op.CallData) < 4 {
+		return common.Address{}, nil, nil, false
+	}
+	method, err := accountExecuteABI.MethodById(op.CallData[:4])
+	if err != nil {
+		return common.Address{}, nil, nil, false
+	}
+
+	args := map[string]interface{}{}
+	if err := method.Inputs.UnpackIntoMap(args, op.CallData[4:]); err != nil {
+		return common.Address{}, nil, nil, false
+	}
+	dest, okDest := args["dest"].(common.Address)
+	value, okValue := args["value"].(*big.Int)
+	calldata, okCalldata := args["func"].([]byte)
+	if !okDest || !okValue || !okCalldata {
+		return common.Address{}, nil, nil, false
+	}
+
+	return dest, value, calldata, true
+}
+
+// Hash returns the userOpHash as computed by EntryPoint.getUserOpHash
+func (op *UserOperation) Hash(entryPoint common.Address, chainID *big.Int) (common.Hash, error) {
+	var packed []byte
+
+	switch op.Version {
+	case EntryPointV06:
+		packed = concatWords(
+			common.LeftPadBytes(op.Sender.Bytes(), 32),
+			math256(op.Nonce),
+			crypto.Keccak256(op.InitCode),
+			crypto.Keccak256(op.CallData),
+			math256(op.CallGasLimit),
+			math256(op.VerificationGasLimit),
+			math256(op.PreVerificationGas),
+			math256(op.MaxFeePerGas),
+			math256(op.MaxPriorityFeePerGas),
+			crypto.Keccak256(op.PaymasterAndData),
+		)
+	case EntryPointV07:
+		accountGasLimits, err := packUint128Pair(op.VerificationGasLimit, op.CallGasLimit)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("invalid gas limits: %v", err)
+		}
+		gasFees, err := packUint128Pair(op.MaxPriorityFeePerGas, op.MaxFeePerGas)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("invalid gas fees: %v", err)
+		}
+		packed = concatWords(
+			common.LeftPadBytes(op.Sender.Bytes(), 32),
+			math256(op.Nonce),
+			crypto.Keccak256(op.InitCode),
+			crypto.Keccak256(op.CallData),
+			accountGasLimits,
+			math256(op.PreVerificationGas),
+			gasFees,
+			crypto.Keccak256(op.PaymasterAndData),
+		)
+	default:
+		return common.Hash{}, fmt.Errorf("unsupported entry point version %s", op.Version)
+	}
+
+	return crypto.Keccak256Hash(
+		crypto.Keccak256(packed),
+		common.LeftPadBytes(entryPoint.Bytes(), 32),
+		math256(chainID),
+	), nil
+}
+
+// TotalGas returns the most gas the operation can be charged for, excluding paymaster gas
+func (op *UserOperation) TotalGas() *big.Int {
+	total := new(big.Int)
+	for _, gas := range []*big.Int{op.CallGasLimit, op.VerificationGasLimit, op.PreVerificationGas} {
+		if gas != nil {
+			total.Add(total, gas)
+		}
+	}
+	return total
+}
+
+// packUint128Pair packs two uint128 values into a single bytes32 word, high first
+func packUint128Pair(high *big.Int, low *big.Int) ([]byte, error) {
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	word := make([]byte, 32)
+	for i, value := range []*big.Int{high, low} {
+		if value == nil {
+			continue
+		}
+		if value.Sign() < 0 || value.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("%s does not fit in uint128", value)
+		}
+		copy(word[i*16:(i+1)*16], common.LeftPadBytes(value.Bytes(), 16))
+	}
+	return word, nil
+}
+
+func concatWords(words ...[]byte) []byte {
+	var packed []byte
+	for _, word := range words {
+		packed = append(packed, word...)
+	}
+	return packed
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// userOpHash of the same operation for the v0.6 and v0.7 EntryPoints on Sepolia,
+// computed with the ABI encoder of go-ethereum as EntryPoint.getUserOpHash does
+func TestUserOperationHash(t *testing.T) {
+	vectors := []struct {
+		version    string
+		entryPoint string
+		hash       string
+	}{
+		{EntryPointV06, "0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789", "0x44a3501ee86a147b517fcfd8ff8234be9ce2e7c0ec77b908f805ae5c7ad0dadb"},
+		{EntryPointV07, "0x0000000071727De22E5E9d8BAf0edAc6f37da032", "0x22f5c7e68091609dcf92677bcfc982970cb709c5eac8bb69dc9c8a7583cf21eb"},
+	}
+
+	for _, vector := range vectors {
+		userOp := &UserOperation{
+			Version:              vector.version,
+			Sender:               common.HexToAddress("0x1306b01bC3e4AD202612D3843387e94737673F53"),
+			Nonce:                big.NewInt(3),
+			InitCode:             hexutil.MustDecode("0x9406cc6185a346906296840746125a0e449764545fbfb9cf000000000000000000000000"),
+			CallData:             hexutil.MustDecode("0xb61d27f6000000000000000000000000000000000000000000000000000000000000dead00000000000000000000000000000000000000000000000000000000000003e800000000000000000000000000000000000000000000000000000000000000600000000000000000000000000000000000000000000000000000000000000000"),
+			CallGasLimit:         big.NewInt(100000),
+			VerificationGasLimit: big.NewInt(200000),
+			PreVerificationGas:   big.NewInt(50000),
+			MaxFeePerGas:         big.NewInt(30000000000),
+			MaxPriorityFeePerGas: big.NewInt(1500000000),
+			PaymasterAndData:     []byte{},
+		}
+
+		hash, err := userOp.Hash(common.HexToAddress(vector.entryPoint), big.NewInt(11155111))
+		if err != nil {
+			t.Fatalf("%s: %v", vector.version, err)
+		}
+		if hash.Hex() != vector.hash {
+			t.Errorf("%s: got userOpHash %s, want %s", vector.version, hash.Hex(), vector.hash)
+		}
+	}
+}
+
+// v0.7 packs the gas limits and fees into 128 bits each
+func TestUserOperationHashRefusesOversizedGas(t *testing.T) {
+	userOp := &UserOperation{
+		Version:              EntryPointV07,
+		Nonce:                big.NewInt(0),
+		CallGasLimit:         new(big.Int).Lsh(big.NewInt(1), 128),
+		VerificationGasLimit: big.NewInt(0),
+	}
+
+	if _, err := userOp.Hash(common.Address{}, big.NewInt(1)); err == nil {
+		t.Fatal("a call gas limit of 2^128 was packed")
+	}
+}
@@ -8,19 +8,43 @@ import (
 	"fmt"
 
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/hashicorp/vault/sdk/logical"
-	"github.com/tyler-smith/go-bip39"
 )
 
+// WalletPath is the storage path of the wallet
+const WalletPath = "wallet"
+
+// Sources the wallet was created from
+const (
+	WalletSourceGenerated = "generated"
+	WalletSourceMnemonic  = "mnemonic"
+	WalletSourceSeed      = "seed"
+	WalletSourceXprv      = "xprv"
+	WalletSourceSLIP39    = "slip39"
+	WalletSourceCeremony  = "ceremony"
+)
+
+// ErrWalletWithoutSeed is returned by the features needing the seed of wallets imported from an extended private key
+var ErrWalletWithoutSeed = errors.New("the wallet was imported from an extended private key and has no seed")
+
 // Wallet stores the seed of wallet
 type Wallet struct {
 	MasterKey string `json:"masterKey"`
 	Seed      string `json:"seed"`
+
+	// Source records what the wallet was created from, empty for wallets
+	// created before it was recorded
+	Source string `json:"source,omitempty"`
+
+	// NonExportable wallets were generated inside the plugin and never reveal
+	// their mnemonic or seed, like the non-exportable keys of Transit
+	NonExportable bool `json:"nonExportable,omitempty"`
 }
 
 // NewWalletFromMnemonic Generate wallet from mnemonic
@@ -30,10 +54,6 @@ func NewWalletFromMnemonic(mnemonic string, passphrase string) (*Wallet, error)
 		return nil, errors.New("mnemonic is required")
 	}
 
-	if !bip39.IsMnemonicValid(mnemonic) {
-		return nil, errors.New("mnemonic is invalid")
-	}
-
 	seed, err := NewSeedFromMnemonic(mnemonic, passphrase)
 	if err != nil {
 		return nil, err
@@ -47,6 +67,44 @@ func NewWalletFromMnemonic(mnemonic string, passphrase string) (*Wallet, error)
 	return wallet, nil
 }
 
+// NewWalletFromSeed Generate wallet from a BIP-32 seed, such as a recovered SLIP-39 master secret
+func NewWalletFromSeed(seed []byte) (*Wallet, error) {
+	if len(seed) == 0 {
+		return nil, errors.New("seed is required")
+	}
+
+	return newWallet(seed)
+}
+
+// NewWalletFromExtendedKey Generate wallet from a BIP-32 master extended private key, xprv or tprv.
+// The wallet has no seed.
+func NewWalletFromExtendedKey(xprv string) (*Wallet, error) {
+	key, err := hdkeychain.NewKeyFromString(xprv)
+	if err != nil {
+		return nil, fmt.Errorf("xprv is invalid: %v", err)
+	}
+
+	if !key.IsForNet(&chaincfg.MainNetParams) && !key.IsForNet(&chaincfg.TestNet3Params) {
+		return nil, errors.New("xprv must be an xprv or tprv extended key")
+	}
+	if !key.IsPrivate() {
+		return nil, errors.New("xprv must be an extended private key")
+	}
+	if key.Depth() != 0 {
+		return nil, fmt.Errorf("xprv must be a master key, not a key at depth %d", key.Depth())
+	}
+
+	return &Wallet{
+		MasterKey: xprv,
+		Source:    WalletSourceXprv,
+	}, nil
+}
+
+// HasSeed reports whether the seed of the wallet is known
+func (w *Wallet) HasSeed() bool {
+	return w.Seed != ""
+}
+
 func newWallet(seed []byte) (*Wallet, error) {
 	masterKey, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
 	if err != nil {
@@ -62,27 +120,59 @@ func newWallet(seed []byte) (*Wallet, error) {
 	}, nil
 }
 
-// NewSeedFromMnemonic returns a BIP-39 seed based on a BIP-39 mnemonic.
+// NewSeedFromMnemonic returns a BIP-39 seed based on a BIP-39 mnemonic of any
+// supported language, NFKD normalizing the mnemonic and passphrase.
 func NewSeedFromMnemonic(mnemonic string, passphrase string) ([]byte, error) {
 	if mnemonic == "" {
 		return nil, errors.New("mnemonic is required")
 	}
 
-	return bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	_, err := MnemonicLanguage(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	return mnemonicSeed(mnemonic, passphrase), nil
+}
+
+// Exportable reports whether the mnemonic and seed of the wallet may leave the plugin
+func (w *Wallet) Exportable() bool {
+	return !w.NonExportable
+}
+
+// MasterFingerprint returns the 4 bytes fingerprint of the master key, the
+// first bytes of the hash160 of its public key
+func (w *Wallet) MasterFingerprint() ([]byte, error) {
+	master, err := hdkeychain.NewKeyFromString(w.MasterKey)
+	if err != nil {
+		return nil, err
+	}
+	masterPublicKey, err := master.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	return btcutil.Hash160(masterPublicKey.SerializeCompressed())[:4], nil
+}
+
+// WalletExists reports whether a wallet is stored
+func WalletExists(ctx context.Context, storage logical.Storage) (bool, error) {
+	entry, err := storage.Get(ctx, WalletPath)
+	if err != nil {
+		return false, err
+	}
+	return entry != nil, nil
 }
 
 // ReadWallet returns wallet JSON (for DEV only)
 func ReadWallet(ctx context.Context, req *logical.Request) (*Wallet, error) {
 
-	walletPath := "wallet"
-
-	entry, err := req.Storage.Get(ctx, walletPath)
+	entry, err := req.Storage.Get(ctx, WalletPath)
 	if err != nil {
 		return nil, err
 	}
 
 	if entry == nil {
-		return nil, fmt.Errorf("entry not existed at %v", walletPath)
+		return nil, fmt.Errorf("entry not existed at %v", WalletPath)
 	}
 
 	var wallet *Wallet
@@ -168,8 +258,8 @@ func MustParseDerivationPath(path string) accounts.DerivationPath {
 	return parsed
 }
 
-// DerivePrivateKey derives the private key of the derivation path.
-func (w *Wallet) derivePrivateKey(path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+// deriveExtendedKey derives the BIP-32 extended private key of the derivation path.
+func (w *Wallet) deriveExtendedKey(path accounts.DerivationPath) (*hdkeychain.ExtendedKey, error) {
 	var err error
 	key, err := hdkeychain.NewKeyFromString(w.MasterKey)
 	if err != nil {
@@ -183,6 +273,16 @@ func (w *Wallet) derivePrivateKey(path accounts.DerivationPath) (*ecdsa.PrivateK
 		}
 	}
 
+	return key, nil
+}
+
+// DerivePrivateKey derives the private key of the derivation path.
+func (w *Wallet) derivePrivateKey(path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	key, err := w.deriveExtendedKey(path)
+	if err != nil {
+		return nil, err
+	}
+
 	privateKey, err := key.ECPrivKey()
 	privateKeyECDSA := privateKey.ToECDSA()
 	if err != nil {
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// Extended public key formats of SLIP-132, named after their mainnet prefix
+const (
+	XpubFormat = "xpub" // BIP-44 and any non-bitcoin chain
+	YpubFormat = "ypub" // BIP-49
+	ZpubFormat = "zpub" // BIP-84
+)
+
+// the mainnet and testnet version bytes of each format, e.g. xpub and tpub
+var xpubVersions = map[string][2]uint32{
+	XpubFormat: {0x0488b21e, 0x043587cf},
+	YpubFormat: {0x049d7cb2, 0x044a5262},
+	ZpubFormat: {0x04b24746, 0x045f1cf6},
+}
+
+// the prefixes of the testnet version bytes of each format
+var testnetXpubFormats = map[string]string{
+	XpubFormat: "tpub",
+	YpubFormat: "upub",
+	ZpubFormat: "vpub",
+}
+
+// ExtendedPublicKey is the watch-only key of an account, with the origin
+// wallets need to recognize the addresses derived from it as their own
+type ExtendedPublicKey struct {
+	Key    string
+	Format string
+
+	// Xpub is the key with the xpub or tpub version bytes, the only ones
+	// output descriptors accept whatever the format
+	Xpub string
+
+	// MasterFingerprint is the hex encoded fingerprint of the master key
+	MasterFingerprint string
+	Path              accounts.DerivationPath
+}
+
+// DefaultXpubFormat returns the format of the purpose of the path, such as
+// zpub for m/84'/0'/0'
+func DefaultXpubFormat(path accounts.DerivationPath) string {
+	if len(path) > 0 {
+		switch path[0] {
+		case hdkeychain.HardenedKeyStart + bitcoinPurposes[AddressTypeP2SHP2WPKH]:
+			return YpubFormat
+		case hdkeychain.HardenedKeyStart + bitcoinPurposes[AddressTypeP2WPKH]:
+			return ZpubFormat
+		}
+	}
+	return XpubFormat
+}
+
+// DeriveExtendedPublicKey derives the extended public key of the hardened
+// path in the format on the bitcoin network, mainnet or testnet versions
+func (w *Wallet) DeriveExtendedPublicKey(path accounts.DerivationPath, format string, network string) (*ExtendedPublicKey, error) {
+	if len(path) == 0 {
+		return nil, errors.New("the master public key is not exported, the path must have an account level")
+	}
+	for _, n := range path {
+		if n < hdkeychain.HardenedKeyStart {
+			return nil, fmt.Errorf("derivation path %s must be hardened at every level", path)
+		}
+	}
+
+	versions, ok := xpubVersions[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format %s, must be xpub, ypub or zpub", format)
+	}
+	_, err := BitcoinNetworkParams(network)
+	if err != nil {
+		return nil, err
+	}
+	version, xpubVersion, name := versions[0], xpubVersions[XpubFormat][0], format
+	if network != NetworkMainnet {
+		version, xpubVersion, name = versions[1], xpubVersions[XpubFormat][1], testnetXpubFormats[format]
+	}
+
+	fingerprint, err := w.MasterFingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := w.deriveExtendedKey(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err = key.Neuter()
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := withXpubVersion(key.String(), version)
+	if err != nil {
+		return nil, err
+	}
+	xpub, err := withXpubVersion(key.String(), xpubVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExtendedPublicKey{
+		Key:               encoded,
+		Format:            name,
+		Xpub:              xpub,
+		MasterFingerprint: hex.EncodeToString(fingerprint),
+		Path:              path,
+	}, nil
+}
+
+// Origin returns the key origin of descriptors, e.g. [d34db33f/84'/0'/0']
+func (k *ExtendedPublicKey) Origin() string {
+	return "[" + k.MasterFingerprint + strings.TrimPrefix(k.Path.String(), "m") + "]"
+}
+
+// DescriptorKey returns the key expression of output descriptors, the key origin
+// followed by the xpub or tpub serialization of the key
+func (k *ExtendedPublicKey) DescriptorKey() string {
+	return k.Origin() + k.Xpub
+}
+
+// withXpubVersion replaces the version bytes of the serialized extended key
+func withXpubVersion(key string, version uint32) (string, error) {
+	decoded := base58.Decode(key)
+	if len(decoded) != 82 {
+		return "", errors.New("extended key is invalid")
+	}
+
+	payload := decoded[:78]
+	binary.BigEndian.PutUint32(payload[:4], version)
+	return base58.Encode(append(payload, chainhash.DoubleHashB(payload)[:4]...)), nil
+}
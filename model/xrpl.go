@@ -0,0 +1,498 @@
+package model
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// ChainXRPL is the chain of XRP Ledger accounts
+const ChainXRPL = "xrpl"
+
+// DefaultXRPLDerivationPath is the path of the first XRP Ledger account
+const DefaultXRPLDerivationPath = "m/44'/144'/0'/0/0"
+
+// Hash prefixes of transactions, STX to sign a single signed transaction and TXN to identify it
+var (
+	xrplSigningPrefix     = []byte{0x53, 0x54, 0x58, 0x00}
+	xrplTransactionPrefix = []byte{0x54, 0x58, 0x4e, 0x00}
+)
+
+// XRP Ledger base58 uses its own alphabet in place of the Bitcoin one
+const (
+	bitcoinAlphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	xrplAlphabet    = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+)
+
+// Type codes of the XRP Ledger binary format
+const (
+	xrplTypeUInt16    = 1
+	xrplTypeUInt32    = 2
+	xrplTypeUInt64    = 3
+	xrplTypeHash128   = 4
+	xrplTypeHash256   = 5
+	xrplTypeAmount    = 6
+	xrplTypeBlob      = 7
+	xrplTypeAccountID = 8
+	xrplTypeSTObject  = 14
+	xrplTypeSTArray   = 15
+	xrplTypeUInt8     = 16
+	xrplTypeHash160   = 17
+	xrplTypePathSet   = 18
+	xrplTypeVector256 = 19
+	xrplTypeUInt96    = 20
+	xrplTypeHash192   = 21
+	xrplTypeUInt384   = 22
+	xrplTypeUInt512   = 23
+	xrplTypeIssue     = 24
+	xrplTypeCurrency  = 26
+)
+
+// Fields of transactions read or written while signing, as type and field codes
+var (
+	xrplFieldTransactionType = [2]int{xrplTypeUInt16, 2}
+	xrplFieldSequence        = [2]int{xrplTypeUInt32, 4}
+	xrplFieldAmount          = [2]int{xrplTypeAmount, 1}
+	xrplFieldFee             = [2]int{xrplTypeAmount, 8}
+	xrplFieldSigningPubKey   = [2]int{xrplTypeBlob, 3}
+	xrplFieldTxnSignature    = [2]int{xrplTypeBlob, 4}
+	xrplFieldAccount         = [2]int{xrplTypeAccountID, 1}
+	xrplFieldDestination     = [2]int{xrplTypeAccountID, 3}
+	xrplObjectEndMarker      = [2]int{xrplTypeSTObject, 1}
+	xrplArrayEndMarker       = [2]int{xrplTypeSTArray, 1}
+)
+
+// Names of common transaction types
+var xrplTransactionTypes = map[uint16]string{
+	0:  "Payment",
+	1:  "EscrowCreate",
+	2:  "EscrowFinish",
+	3:  "AccountSet",
+	4:  "EscrowCancel",
+	5:  "SetRegularKey",
+	7:  "OfferCreate",
+	8:  "OfferCancel",
+	10: "TicketCreate",
+	12: "SignerListSet",
+	13: "PaymentChannelCreate",
+	14: "PaymentChannelFund",
+	15: "PaymentChannelClaim",
+	16: "CheckCreate",
+	17: "CheckCash",
+	18: "CheckCancel",
+	19: "DepositPreauth",
+	20: "TrustSet",
+	21: "AccountDelete",
+	25: "NFTokenMint",
+	26: "NFTokenBurn",
+	27: "NFTokenCreateOffer",
+	28: "NFTokenCancelOffer",
+	29: "NFTokenAcceptOffer",
+}
+
+// XRPLAddress returns the classic address of the secp256k1 public key
+func XRPLAddress(publicKey *btcec.PublicKey) string {
+	return XRPLEncodeAccountID(btcutil.Hash160(publicKey.SerializeCompressed()))
+}
+
+// XRPLEncodeAccountID returns the classic address of a 20 bytes account ID
+func XRPLEncodeAccountID(accountID []byte) string {
+	encoded := base58.CheckEncode(accountID, 0x00)
+	return strings.Map(func(r rune) rune {
+		return rune(xrplAlphabet[strings.IndexRune(bitcoinAlphabet, r)])
+	}, encoded)
+}
+
+// DeriveXRPL derives an XRP Ledger account
+func (w *Wallet) DeriveXRPL(path accounts.DerivationPath) (*Account, error) {
+	key, err := w.deriveExtendedKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	URL := accounts.URL{
+		Scheme: "",
+		Path:   path.String(),
+	}
+
+	return &Account{
+		Address:    XRPLAddress(privateKey.PubKey()),
+		URL:        URL.String(),
+		PrivateKey: hex.EncodeToString(privateKey.Serialize()),
+		PublicKey:  hex.EncodeToString(privateKey.PubKey().SerializeCompressed()),
+		Chain:      ChainXRPL,
+	}, nil
+}
+
+// xrplField is a top level field of a serialized transaction, located by
+// its start and end in the blob. Value excludes the length prefix.
+type xrplField struct {
+	code  [2]int
+	start int
+	end   int
+	value []byte
+}
+
+// XRPLTransaction is a transaction in the XRP Ledger binary format
+type XRPLTransaction struct {
+	blob   []byte
+	fields []xrplField
+}
+
+// ParseXRPLTransaction parses a binary serialized transaction, checking its
+// fields are in canonical order
+func ParseXRPLTransaction(blob []byte) (*XRPLTransaction, error) {
+	reader := &xrplReader{data: blob}
+	tx := &XRPLTransaction{blob: blob}
+	for reader.pos < len(blob) {
+		start := reader.pos
+		code, err := reader.readFieldHeader()
+		if err != nil {
+			return nil, err
+		}
+		value, err := reader.readValue(code[0])
+		if err != nil {
+			return nil, err
+		}
+		if n := len(tx.fields); n > 0 && !xrplFieldBefore(tx.fields[n-1].code, code) {
+			return nil, fmt.Errorf("field %d.%d of the transaction is not in canonical order", code[0], code[1])
+		}
+		tx.fields = append(tx.fields, xrplField{code: code, start: start, end: reader.pos, value: value})
+	}
+	if len(tx.fields) == 0 {
+		return nil, errors.New("Fail to decode XRP Ledger transaction: empty blob")
+	}
+	return tx, nil
+}
+
+func xrplFieldBefore(a [2]int, b [2]int) bool {
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+
+func (tx *XRPLTransaction) field(code [2]int) []byte {
+	for _, field := range tx.fields {
+		if field.code == code {
+			return field.value
+		}
+	}
+	return nil
+}
+
+// TransactionType returns the name of the transaction type, or its code if unknown
+func (tx *XRPLTransaction) TransactionType() string {
+	value := tx.field(xrplFieldTransactionType)
+	if len(value) != 2 {
+		return ""
+	}
+	code := binary.BigEndian.Uint16(value)
+	if name, ok := xrplTransactionTypes[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("%d", code)
+}
+
+// Account returns the classic address of the sending account
+func (tx *XRPLTransaction) Account() string {
+	return tx.accountField(xrplFieldAccount)
+}
+
+// Destination returns the classic address of the destination, if any
+func (tx *XRPLTransaction) Destination() string {
+	return tx.accountField(xrplFieldDestination)
+}
+
+func (tx *XRPLTransaction) accountField(code [2]int) string {
+	value := tx.field(code)
+	if len(value) != 20 {
+		return ""
+	}
+	return XRPLEncodeAccountID(value)
+}
+
+// Sequence returns the sequence number of the transaction
+func (tx *XRPLTransaction) Sequence() uint32 {
+	value := tx.field(xrplFieldSequence)
+	if len(value) != 4 {
+		return 0
+	}
+	return binary.BigEndian.Uint32(value)
+}
+
+// Amount returns the XRP amount in drops, empty for tokens or without amount
+func (tx *XRPLTransaction) Amount() string {
+	return xrplDrops(tx.field(xrplFieldAmount))
+}
+
+// Fee returns the fee in drops
+func (tx *XRPLTransaction) Fee() string {
+	return xrplDrops(tx.field(xrplFieldFee))
+}
+
+func xrplDrops(value []byte) string {
+	if len(value) != 8 || value[0]&0x80 != 0 {
+		return ""
+	}
+	// the second bit tells a positive amount, the remaining 62 bits the drops
+	return fmt.Sprintf("%d", binary.BigEndian.Uint64(value)&0x3fffffffffffffff)
+}
+
+// SigningPubKey returns the public key the transaction expects to be signed with
+func (tx *XRPLTransaction) SigningPubKey() []byte {
+	return tx.field(xrplFieldSigningPubKey)
+}
+
+// IsSigned tells whether the transaction already carries a signature
+func (tx *XRPLTransaction) IsSigned() bool {
+	return tx.field(xrplFieldTxnSignature) != nil
+}
+
+// SigningHash returns the SHA-512Half of the transaction prefixed with STX
+func (tx *XRPLTransaction) SigningHash() []byte {
+	return xrplSHA512Half(xrplSigningPrefix, tx.blob)
+}
+
+// Sign signs the transaction with a DER encoded low-S signature and returns
+// the signature and the signed blob, carrying the TxnSignature field
+func (tx *XRPLTransaction) Sign(privateKey *btcec.PrivateKey) ([]byte, []byte, error) {
+	if tx.IsSigned() {
+		return nil, nil, errors.New("the transaction is already signed")
+	}
+	if !bytes.Equal(tx.SigningPubKey(), privateKey.PubKey().SerializeCompressed()) {
+		return nil, nil, errors.New("the SigningPubKey of the transaction is not the public key of the account")
+	}
+
+	signature, err := privateKey.Sign(tx.SigningHash())
+	if err != nil {
+		return nil, nil, err
+	}
+	der := signature.Serialize()
+
+	// TxnSignature goes before the first field following it in canonical order
+	insertAt := len(tx.blob)
+	for _, field := range tx.fields {
+		if xrplFieldBefore(xrplFieldTxnSignature, field.code) {
+			insertAt = field.start
+			break
+		}
+	}
+	signed := append([]byte{}, tx.blob[:insertAt]...)
+	signed = append(signed, byte(xrplFieldTxnSignature[0]<<4|xrplFieldTxnSignature[1]))
+	signed = append(signed, xrplEncodeLength(len(der))...)
+	signed = append(signed, der...)
+	signed = append(signed, tx.blob[insertAt:]...)
+
+	return der, signed, nil
+}
+
+// XRPLTransactionHash returns the hash identifying a signed transaction
+func XRPLTransactionHash(signedBlob []byte) []byte {
+	return xrplSHA512Half(xrplTransactionPrefix, signedBlob)
+}
+
+func xrplSHA512Half(prefix []byte, data []byte) []byte {
+	hash := sha512.Sum512(append(append([]byte{}, prefix...), data...))
+	return hash[:32]
+}
+
+func xrplEncodeLength(length int) []byte {
+	switch {
+	case length <= 192:
+		return []byte{byte(length)}
+	case length <= 12480:
+		length -= 193
+		return []byte{byte(193 + length>>8), byte(length)}
+	}
+	length -= 12481
+	return []byte{byte(241 + length>>16), byte(length >> 8), byte(length)}
+}
+
+// xrplReader reads the fields of the XRP Ledger binary format
+type xrplReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *xrplReader) readBytes(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.data) {
+		return nil, errors.New("Fail to decode XRP Ledger transaction: unexpected end of data")
+	}
+	value := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return value, nil
+}
+
+func (r *xrplReader) readByte() (int, error) {
+	value, err := r.readBytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return int(value[0]), nil
+}
+
+// readFieldHeader reads the type and field codes, each taking a nibble of the
+// first byte when lower than 16 and a following byte otherwise
+func (r *xrplReader) readFieldHeader() ([2]int, error) {
+	first, err := r.readByte()
+	if err != nil {
+		return [2]int{}, err
+	}
+	typeCode, fieldCode := first>>4, first&0x0f
+	if typeCode == 0 {
+		if typeCode, err = r.readByte(); err != nil {
+			return [2]int{}, err
+		}
+	}
+	if fieldCode == 0 {
+		if fieldCode, err = r.readByte(); err != nil {
+			return [2]int{}, err
+		}
+	}
+	return [2]int{typeCode, fieldCode}, nil
+}
+
+func (r *xrplReader) readLength() (int, error) {
+	first, err := r.readByte()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case first <= 192:
+		return first, nil
+	case first <= 240:
+		second, err := r.readByte()
+		if err != nil {
+			return 0, err
+		}
+		return 193 + (first-193)*256 + second, nil
+	case first <= 254:
+		rest, err := r.readBytes(2)
+		if err != nil {
+			return 0, err
+		}
+		return 12481 + (first-241)*65536 + int(rest[0])*256 + int(rest[1]), nil
+	}
+	return 0, errors.New("Fail to decode XRP Ledger transaction: invalid length prefix")
+}
+
+// readValue reads the value of a field of the type, returning the content of
+// length prefixed values and the raw bytes otherwise
+func (r *xrplReader) readValue(typeCode int) ([]byte, error) {
+	switch typeCode {
+	case xrplTypeUInt8:
+		return r.readBytes(1)
+	case xrplTypeUInt16:
+		return r.readBytes(2)
+	case xrplTypeUInt32:
+		return r.readBytes(4)
+	case xrplTypeUInt64:
+		return r.readBytes(8)
+	case xrplTypeUInt96:
+		return r.readBytes(12)
+	case xrplTypeHash128:
+		return r.readBytes(16)
+	case xrplTypeHash160, xrplTypeCurrency:
+		return r.readBytes(20)
+	case xrplTypeHash192:
+		return r.readBytes(24)
+	case xrplTypeHash256:
+		return r.readBytes(32)
+	case xrplTypeUInt384:
+		return r.readBytes(48)
+	case xrplTypeUInt512:
+		return r.readBytes(64)
+	case xrplTypeAmount:
+		if r.pos >= len(r.data) {
+			return nil, errors.New("Fail to decode XRP Ledger transaction: unexpected end of data")
+		}
+		switch {
+		case r.data[r.pos]&0x80 != 0:
+			// token amount, followed by its currency and issuer
+			return r.readBytes(48)
+		case r.data[r.pos]&0x20 != 0:
+			// multi-purpose token amount, followed by its issuance ID
+			return r.readBytes(33)
+		}
+		return r.readBytes(8)
+	case xrplTypeBlob, xrplTypeAccountID, xrplTypeVector256:
+		length, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		return r.readBytes(length)
+	case xrplTypeIssue:
+		currency, err := r.readBytes(20)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(currency, make([]byte, 20)) {
+			return currency, nil
+		}
+		if _, err := r.readBytes(20); err != nil {
+			return nil, err
+		}
+		return r.data[r.pos-40 : r.pos], nil
+	case xrplTypeSTObject:
+		return r.readContainer(xrplObjectEndMarker)
+	case xrplTypeSTArray:
+		return r.readContainer(xrplArrayEndMarker)
+	case xrplTypePathSet:
+		return r.readPathSet()
+	}
+	return nil, fmt.Errorf("Fail to decode XRP Ledger transaction: unsupported field type %d", typeCode)
+}
+
+// readContainer reads the fields of an object, or the objects of an array, up to the end marker
+func (r *xrplReader) readContainer(endMarker [2]int) ([]byte, error) {
+	start := r.pos
+	for {
+		code, err := r.readFieldHeader()
+		if err != nil {
+			return nil, err
+		}
+		if code == endMarker {
+			return r.data[start:r.pos], nil
+		}
+		if _, err := r.readValue(code[0]); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readPathSet reads the steps of the paths, separated by 0xff and ended by 0x00
+func (r *xrplReader) readPathSet() ([]byte, error) {
+	start := r.pos
+	for {
+		stepType, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if stepType == 0x00 {
+			return r.data[start:r.pos], nil
+		}
+		if stepType == 0xff {
+			continue
+		}
+		// account, currency and issuer of the step, 20 bytes each
+		for _, flag := range []int{0x01, 0x10, 0x20} {
+			if stepType&flag != 0 {
+				if _, err := r.readBytes(20); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package model
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+func TestXRPLEncodeAccountID(t *testing.T) {
+	vectors := []struct {
+		accountID string
+		address   string
+	}{
+		// ACCOUNT_ZERO and ACCOUNT_ONE
+		{"0000000000000000000000000000000000000000", "rrrrrrrrrrrrrrrrrrrrrhoLvTp"},
+		{"0000000000000000000000000000000000000001", "rrrrrrrrrrrrrrrrrrrrBZbvji"},
+		// the genesis account of the passphrase "masterpassphrase"
+		{"B5F762798A53D543A014CAF8B297CFF8F2F937E8", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+	}
+
+	for _, vector := range vectors {
+		if address := XRPLEncodeAccountID(mustDecodeHex(t, strings.ToLower(vector.accountID))); address != vector.address {
+			t.Errorf("got %s for account ID %s, want %s", address, vector.accountID, vector.address)
+		}
+	}
+}
+
+func TestXRPLAddress(t *testing.T) {
+	publicKey, err := btcec.ParsePubKey(mustDecodeHex(t, "0330e7fc9d56bb25d6893ba3f317ae5bcf33b3291bd63db32654a313222f7fd020"), btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address := XRPLAddress(publicKey); address != "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh" {
+		t.Errorf("got %s, want the genesis account", address)
+	}
+
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := wallet.DeriveXRPL(MustParseDerivationPath(DefaultXRPLDerivationPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Address != "rHsMGQEkVNJmpGWs8XUBoTBiAAbwxZN5v3" {
+		t.Errorf("got %s, want rHsMGQEkVNJmpGWs8XUBoTBiAAbwxZN5v3", account.Address)
+	}
+}
+
+// the OfferCreate example of the XRP Ledger binary format documentation
+const (
+	xrplOfferCreateBlob      = "120007220008000024001ABED82A2380BF2C2019001ABED764D55920AC9391400000000000000000000000000055534400000000000A20B3C85F482532A9578DBB3950B85CA06594D165400000037E11D60068400000000000000A732103EE83BB432547885C219634A1BC407A9DB0474145D69737D09CCDC63E1DEE7FE3744630440220143759437C04F7B61F012563AFE90D8DAFC46E86035E1D965A9CED282C97D4CE02204CFD241E86F17E011298FC1A39B63386C74306A5DE047E213B0F29EFA4571C2C8114DD76483FACDEE26E60D8A586BB58D09F27045C46"
+	xrplOfferCreateSignature = "30440220143759437C04F7B61F012563AFE90D8DAFC46E86035E1D965A9CED282C97D4CE02204CFD241E86F17E011298FC1A39B63386C74306A5DE047E213B0F29EFA4571C2C"
+	xrplOfferCreateHash      = "73734B611DDA23D3F5F62E20A173B78AB8406AC5015094DA53F53D39B9EDB06C"
+)
+
+func TestParseXRPLTransaction(t *testing.T) {
+	blob := mustDecodeHex(t, strings.ToLower(xrplOfferCreateBlob))
+	tx, err := ParseXRPLTransaction(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tx.TransactionType() != "OfferCreate" {
+		t.Errorf("got transaction type %s, want OfferCreate", tx.TransactionType())
+	}
+	if tx.Account() != "rMBzp8CgpE441cp5PVyA9rpVV7oT8hP3ys" {
+		t.Errorf("got account %s, want rMBzp8CgpE441cp5PVyA9rpVV7oT8hP3ys", tx.Account())
+	}
+	if tx.Sequence() != 1752792 {
+		t.Errorf("got sequence %d, want 1752792", tx.Sequence())
+	}
+	if tx.Fee() != "10" {
+		t.Errorf("got fee %s, want 10", tx.Fee())
+	}
+	if !tx.IsSigned() {
+		t.Error("the transaction is not signed")
+	}
+	if hash := strings.ToUpper(hex.EncodeToString(XRPLTransactionHash(blob))); hash != xrplOfferCreateHash {
+		t.Errorf("got hash %s, want %s", hash, xrplOfferCreateHash)
+	}
+
+	// the signature verifies against the signing hash of the blob without it
+	signatureField := "7446" + xrplOfferCreateSignature
+	unsigned, err := ParseXRPLTransaction(mustDecodeHex(t, strings.ToLower(strings.Replace(xrplOfferCreateBlob, signatureField, "", 1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := btcec.ParsePubKey(unsigned.SigningPubKey(), btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := btcec.ParseDERSignature(mustDecodeHex(t, strings.ToLower(xrplOfferCreateSignature)), btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !signature.Verify(unsigned.SigningHash(), publicKey) {
+		t.Error("signature does not verify against the signing hash")
+	}
+
+	// fields out of canonical order
+	if _, err := ParseXRPLTransaction(mustDecodeHex(t, "2400000001120000")); err == nil {
+		t.Error("non canonical transaction is accepted")
+	}
+}
+
+func TestSignXRPLTransaction(t *testing.T) {
+	wallet, err := NewWalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := wallet.DeriveXRPL(MustParseDerivationPath(DefaultXRPLDerivationPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, account.PrivateKey))
+
+	// the unsigned OfferCreate with the SigningPubKey of the account
+	oldPubKey := "732103EE83BB432547885C219634A1BC407A9DB0474145D69737D09CCDC63E1DEE7FE3"
+	unsignedHex := strings.Replace(xrplOfferCreateBlob, "7446"+xrplOfferCreateSignature, "", 1)
+	unsignedHex = strings.ToLower(strings.Replace(unsignedHex, oldPubKey, "7321"+strings.ToUpper(account.PublicKey), 1))
+	tx, err := ParseXRPLTransaction(mustDecodeHex(t, unsignedHex))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature, signedBlob, err := tx.Sign(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// TxnSignature is inserted between SigningPubKey and Account
+	field := append([]byte{0x74, byte(len(signature))}, signature...)
+	pubKeyEnd := strings.Index(unsignedHex, "8114") / 2
+	want := append(append(mustDecodeHex(t, unsignedHex[:pubKeyEnd*2]), field...), mustDecodeHex(t, unsignedHex[pubKeyEnd*2:])...)
+	if !bytes.Equal(signedBlob, want) {
+		t.Errorf("got signed blob %x, want %x", signedBlob, want)
+	}
+
+	parsed, err := btcec.ParseDERSignature(signature, btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Verify(tx.SigningHash(), privateKey.PubKey()) {
+		t.Error("signature does not verify")
+	}
+	if _, err := ParseXRPLTransaction(signedBlob); err != nil {
+		t.Error(err)
+	}
+
+	otherKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), bytes.Repeat([]byte{0x01}, 32))
+	if _, _, err := tx.Sign(otherKey); err == nil {
+		t.Error("signing with a key other than the SigningPubKey is accepted")
+	}
+}
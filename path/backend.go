@@ -1,23 +1,54 @@
 package path
 
 import (
+	"sync"
+	"vault-hd-wallet/model"
+
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
 // Backend returns the backend
 func Backend(conf *logical.BackendConfig) (*PluginBackend, error) {
 	var b PluginBackend
+	b.historyLocks = locksutil.CreateLocks()
 	b.Backend = &framework.Backend{
 		Help: "",
-		Paths: framework.PathAppend(
+		Paths: withRolePaths(framework.PathAppend(
+			ConfigPaths(&b),
 			AccountPaths(&b),
 			WalletPaths(&b),
-		),
+			SelfPaths(&b),
+			ContractPaths(&b),
+			HistoryPaths(&b),
+			SIWEPaths(&b),
+			ERC20Paths(&b),
+			FeeLimitPaths(&b),
+			ChainPaths(&b),
+			SafePaths(&b),
+			UserOperationPaths(&b),
+			PSBTPaths(&b),
+			BitcoinMessagePaths(&b),
+			SolanaPaths(&b),
+			CosmosPaths(&b),
+			TronPaths(&b),
+			XRPLPaths(&b),
+			RolePaths(&b),
+			SLIP39Paths(&b),
+			CeremonyPaths(&b),
+			XpubPaths(&b),
+		)),
 		PathsSpecial: &logical.Paths{
 			SealWrapStorage: []string{
 				"accounts/",
+				model.WalletPath,
 				"wallet/",
+				model.SLIP39SharesPrefix,
+				model.CeremonyPath,
+				model.CeremonySharesPrefix,
+				model.HistoryPrefix,
+				model.HistoryKeyPath,
 			},
 		},
 		Secrets:     []*framework.Secret{},
@@ -29,4 +60,12 @@ func Backend(conf *logical.BackendConfig) (*PluginBackend, error) {
 // PluginBackend implements the Backend for this plugin
 type PluginBackend struct {
 	*framework.Backend
+
+	historyLocks []*locksutil.LockEntry
+
+	// selfLock serializes the provisioning of self accounts
+	selfLock sync.Mutex
+
+	// ceremonyLock serializes the contributions to the key ceremony
+	ceremonyLock sync.Mutex
 }
@@ -3,7 +3,10 @@ package path
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/big"
+	"strings"
 	"vault-hd-wallet/model"
 	"vault-hd-wallet/utils"
 
@@ -29,7 +32,53 @@ func AccountPaths(b *PluginBackend) []*framework.Path {
 					Type: framework.TypeString,
 				},
 				"derivationPath": {
-					Type: framework.TypeString,
+					Type:        framework.TypeString,
+					Description: "The BIP-32 derivation path, relative paths of ethereum accounts being appended to the default derivation prefix of the mount config. Optional for bitcoin accounts, which default to the first address of the BIP matching the address type, solana accounts, which default to m/44'/501'/0'/0', cosmos accounts, which default to m/44'/<coin_type>'/0'/0/0, tron accounts, which default to m/44'/195'/0'/0/0, and xrpl accounts, which default to m/44'/144'/0'/0/0.",
+				},
+				"chain": {
+					Type:        framework.TypeString,
+					Description: "The chain of the account, ethereum, bitcoin, solana, cosmos, tron, xrpl or the name of a chain profile - defaults to ethereum.",
+					Default:     model.ChainEthereum,
+				},
+				"address_type": {
+					Type:        framework.TypeString,
+					Description: "The bitcoin address type, p2pkh, p2sh-p2wpkh, p2wpkh or p2tr - defaults to p2wpkh.",
+					Default:     model.AddressTypeP2WPKH,
+				},
+				"network": {
+					Type:        framework.TypeString,
+					Description: "The bitcoin network, mainnet, testnet or regtest - defaults to mainnet.",
+					Default:     model.NetworkMainnet,
+				},
+				"bech32_prefix": {
+					Type:        framework.TypeString,
+					Description: "The bech32 prefix of cosmos addresses - defaults to cosmos.",
+					Default:     model.DefaultCosmosPrefix,
+				},
+				"coin_type": {
+					Type:        framework.TypeInt,
+					Description: "The BIP-44 coin type of the default cosmos derivation path - defaults to 118.",
+					Default:     model.DefaultCosmosCoinType,
+				},
+				"bind_entity": {
+					Type:        framework.TypeBool,
+					Description: "Bind the account to the requesting identity entity, refusing signatures requested by any other entity.",
+				},
+				"entity_id": {
+					Type:        framework.TypeString,
+					Description: "The ID of the identity entity the account is bound to. Empty unbinds the account.",
+				},
+				"require_known_calldata": {
+					Type:        framework.TypeBool,
+					Description: "Refuse to sign calldata which does not decode against a registered contract ABI.",
+				},
+				"allowed_entry_points": {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "The ERC-4337 EntryPoints user operations may be signed for. Empty allows any.",
+				},
+				"allowed_senders": {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "The smart accounts user operations may be signed for. Empty allows any.",
 				},
 			},
 			Operations: map[logical.Operation]framework.OperationHandler{
@@ -37,6 +86,10 @@ func AccountPaths(b *PluginBackend) []*framework.Path {
 					Callback: b.createAccount,
 					Summary:  "create a account",
 				},
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.updateAccount,
+					Summary:  "update the settings of an account",
+				},
 			},
 		},
 		{
@@ -99,41 +152,7 @@ func AccountPaths(b *PluginBackend) []*framework.Path {
 			HelpSynopsis:    "sign a transaction",
 			HelpDescription: `sign a transaction`,
 			ExistenceCheck:  utils.PathExistenceCheck,
-			Fields: map[string]*framework.FieldSchema{
-				"name": {
-					Type: framework.TypeString,
-				},
-				"address_to": {
-					Type:        framework.TypeString,
-					Description: "The address of the account to send tx to.",
-				},
-				"data": {
-					Type:        framework.TypeString,
-					Description: "The data to sign.",
-				},
-				"amount": {
-					Type:        framework.TypeString,
-					Description: "Amount of ETH (in wei).",
-				},
-				"nonce": {
-					Type:        framework.TypeString,
-					Description: "The transaction nonce.",
-				},
-				"gas_limit": {
-					Type:        framework.TypeString,
-					Description: "The gas limit for the transaction - defaults to 21000.",
-					Default:     "21000",
-				},
-				"gas_price": {
-					Type:        framework.TypeString,
-					Description: "The gas price for the transaction in wei.",
-					Default:     "0",
-				},
-				"chainID": {
-					Type:        framework.TypeString,
-					Description: "The chain ID of the blockchain network.",
-				},
-			},
+			Fields:          signTransactionFields(),
 			Operations: map[logical.Operation]framework.OperationHandler{
 				logical.CreateOperation: &framework.PathOperation{
 					Callback: b.signTransaction,
@@ -144,12 +163,142 @@ func AccountPaths(b *PluginBackend) []*framework.Path {
 	}
 }
 
+// signTransactionFields returns the fields of the sign-tx paths
+func signTransactionFields() map[string]*framework.FieldSchema {
+	return map[string]*framework.FieldSchema{
+		"name": {
+			Type: framework.TypeString,
+		},
+		"address_to": {
+			Type:        framework.TypeString,
+			Description: "The address of the account to send tx to.",
+		},
+		"data": {
+			Type:        framework.TypeString,
+			Description: "The data to sign.",
+		},
+		"amount": {
+			Type:        framework.TypeString,
+			Description: "Amount of ETH (in wei).",
+		},
+		"nonce": {
+			Type:        framework.TypeString,
+			Description: "The transaction nonce.",
+		},
+		"gas_limit": {
+			Type:        framework.TypeString,
+			Description: "The gas limit for the transaction - defaults to the default gas limit of the chain, or 21000.",
+		},
+		"gas_price": {
+			Type:        framework.TypeString,
+			Description: "The gas price for the transaction in wei.",
+			Default:     "0",
+		},
+		"chainID": {
+			Type:        framework.TypeString,
+			Description: "The chain ID of the blockchain network - defaults to the chain ID of the chain.",
+		},
+		"chain": {
+			Type:        framework.TypeString,
+			Description: "The name of the chain profile providing the chain ID and gas limit - defaults to the chain the account was created for.",
+		},
+		"override_fee_limits": {
+			Type:        framework.TypeBool,
+			Description: "Sign even if the fee violates the fee limits of the chain, when the mount config allows fee limit overrides.",
+		},
+	}
+}
+
 func (b *PluginBackend) createAccount(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 	dataWrapper := utils.NewFieldDataWrapper(data)
 
-	derivationPathField, err := dataWrapper.MustGetString("derivationPath")
+	if name := data.Get("name").(string); model.IsSelfAccountName(name) {
+		return nil, fmt.Errorf("account names starting with %s are reserved for self accounts", model.SelfAccountPrefix)
+	}
+
+	chain := dataWrapper.GetString("chain", model.ChainEthereum)
+	addressType := dataWrapper.GetString("address_type", model.AddressTypeP2WPKH)
+	network := dataWrapper.GetString("network", model.NetworkMainnet)
+	bech32Prefix := dataWrapper.GetString("bech32_prefix", model.DefaultCosmosPrefix)
+
+	derivationPathField := dataWrapper.GetString("derivationPath", "")
+
+	// a configured chain profile provides the parameters not given explicitly,
+	// the built-in chains never being looked up as profiles
+	var profile *model.ChainProfile
+	var err error
+	if !model.IsBuiltinChain(chain) {
+		profile, err = model.ReadChainProfile(ctx, req.Storage, chain)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if profile != nil {
+		chain = profile.Chain()
+		switch profile.Family {
+		case model.FamilyBitcoin:
+			if _, ok := data.GetOk("address_type"); !ok {
+				addressType = profile.AddressFormat
+			}
+			if _, ok := data.GetOk("network"); !ok {
+				network = profile.ChainID
+			}
+		case model.FamilyCosmos:
+			if _, ok := data.GetOk("bech32_prefix"); !ok {
+				bech32Prefix = profile.AddressFormat
+			}
+		}
+		if derivationPathField == "" {
+			derivationPathField, err = profile.DefaultDerivationPath(addressType)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	config, err := model.ReadConfig(ctx, req.Storage)
 	if err != nil {
-		return nil, utils.ErrorHandler("derivationPathField", err)
+		return nil, err
+	}
+	if !config.AllowsChain(chain) {
+		return nil, fmt.Errorf("%s accounts are not allowed by the mount config", chain)
+	}
+
+	switch chain {
+	case model.ChainEthereum:
+		if derivationPathField == "" {
+			return nil, utils.ErrorHandler("derivationPathField", errors.New("derivationPath is required"))
+		}
+	case model.ChainBitcoin:
+		if derivationPathField == "" {
+			var err error
+			derivationPathField, err = model.DefaultBitcoinDerivationPath(addressType, network)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case model.ChainSolana:
+		if derivationPathField == "" {
+			derivationPathField = model.SolanaDerivationPath(0)
+		}
+	case model.ChainCosmos:
+		if derivationPathField == "" {
+			coinType := data.Get("coin_type").(int)
+			if coinType < 0 || coinType >= 0x80000000 {
+				return nil, fmt.Errorf("invalid coin_type %d", coinType)
+			}
+			derivationPathField = model.CosmosDerivationPath(uint32(coinType))
+		}
+	case model.ChainTron:
+		if derivationPathField == "" {
+			derivationPathField = model.DefaultTronDerivationPath
+		}
+	case model.ChainXRPL:
+		if derivationPathField == "" {
+			derivationPathField = model.DefaultXRPLDerivationPath
+		}
+	default:
+		return nil, fmt.Errorf("unsupported chain %s", chain)
 	}
 
 	wallet, err := model.ReadWallet(ctx, req)
@@ -157,12 +306,45 @@ func (b *PluginBackend) createAccount(ctx context.Context, req *logical.Request,
 		return nil, err
 	}
 
+	derivationPathField, err = config.ResolveDerivationPath(chain, derivationPathField)
+	if err != nil {
+		return nil, err
+	}
 	derivationPath, err := hdwallet.ParseDerivationPath(derivationPathField)
 	if err != nil {
 		return nil, err
 	}
+	// the keys of the branch belong to the entities of the self-provisioned accounts
+	if model.IsSelfDerivationPath(derivationPath) {
+		return nil, fmt.Errorf("derivation path %s is reserved for self-provisioned accounts", derivationPath)
+	}
 
-	account, err := wallet.Derive(derivationPath)
+	var account *model.Account
+	switch chain {
+	case model.ChainBitcoin:
+		account, err = wallet.DeriveBitcoin(derivationPath, addressType, network)
+	case model.ChainSolana:
+		account, err = wallet.DeriveSolana(derivationPath)
+	case model.ChainCosmos:
+		account, err = wallet.DeriveCosmos(derivationPath, bech32Prefix)
+	case model.ChainTron:
+		account, err = wallet.DeriveTron(derivationPath)
+	case model.ChainXRPL:
+		account, err = wallet.DeriveXRPL(derivationPath)
+	default:
+		account, err = wallet.Derive(derivationPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if profile != nil {
+		account.Profile = profile.Name
+	}
+	err = applyEntityBinding(account, req, data)
+	if err != nil {
+		return nil, err
+	}
+	err = applyAccountSettings(account, data)
 	if err != nil {
 		return nil, err
 	}
@@ -178,30 +360,152 @@ func (b *PluginBackend) createAccount(ctx context.Context, req *logical.Request,
 		return nil, err
 	}
 
+	err = model.StartHistory(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, utils.ErrorHandler("history", err)
+	}
+
+	return &logical.Response{
+		Data: accountAddressData(account),
+	}, nil
+}
+
+func (b *PluginBackend) updateAccount(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if name := data.Get("name").(string); model.IsSelfAccountName(name) {
+		return nil, fmt.Errorf("account names starting with %s are reserved for self accounts", model.SelfAccountPrefix)
+	}
+
+	entry, err := req.Storage.Get(ctx, req.Path)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("account %s is not existed", req.Path)
+	}
+
+	var account *model.Account
+	err = entry.DecodeJSON(&account)
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyEntityBinding(account, req, data)
+	if err != nil {
+		return nil, err
+	}
+	err = applyAccountSettings(account, data)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err = logical.StorageEntryJSON(req.Path, account)
+	if err != nil {
+		return nil, err
+	}
+
+	err = req.Storage.Put(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
 	return &logical.Response{
 		Data: map[string]interface{}{
-			"address": account.Address,
+			"address":                account.Address,
+			"require_known_calldata": account.RequireKnownCalldata,
+			"allowed_entry_points":   account.AllowedEntryPoints,
+			"allowed_senders":        account.AllowedSenders,
+			"entity_id":              account.EntityID,
 		},
 	}, nil
 }
 
+// applyEntityBinding binds the account to the entity of the request or to the entity ID of the request data
+func applyEntityBinding(account *model.Account, req *logical.Request, data *framework.FieldData) error {
+	if value, ok := data.GetOk("entity_id"); ok {
+		account.EntityID = value.(string)
+	}
+
+	if value, ok := data.GetOk("bind_entity"); ok && value.(bool) {
+		if req.EntityID == "" {
+			return errors.New("bind_entity requires a request from an identity entity")
+		}
+		account.EntityID = req.EntityID
+	}
+
+	return nil
+}
+
+// applyAccountSettings copies the settings present in the request to the account
+func applyAccountSettings(account *model.Account, data *framework.FieldData) error {
+	if value, ok := data.GetOk("require_known_calldata"); ok {
+		account.RequireKnownCalldata = value.(bool)
+	}
+
+	for field, setting := range map[string]*[]string{
+		"allowed_entry_points": &account.AllowedEntryPoints,
+		"allowed_senders":      &account.AllowedSenders,
+	} {
+		value, ok := data.GetOk(field)
+		if !ok {
+			continue
+		}
+		addresses := []string{}
+		for _, address := range value.([]string) {
+			if !common.IsHexAddress(address) {
+				return fmt.Errorf("invalid address %s in %s", address, field)
+			}
+			addresses = append(addresses, common.HexToAddress(address).Hex())
+		}
+		*setting = addresses
+	}
+
+	return nil
+}
+
 func (b *PluginBackend) readAddress(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 
-	account, err := model.ReadAccount(ctx, req)
+	account, err := readAllowedAccount(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
 	return &logical.Response{
-		Data: map[string]interface{}{
-			"address": account.Address,
-		},
+		Data: accountAddressData(account),
 	}, nil
 }
 
+// accountAddressData returns the address of the account along with the chain it belongs to
+func accountAddressData(account *model.Account) map[string]interface{} {
+	result := map[string]interface{}{
+		"address": account.Address,
+		"chain":   account.ChainName(),
+	}
+	if account.Profile != "" {
+		result["profile"] = account.Profile
+	}
+	if account.EntityID != "" {
+		result["entity_id"] = account.EntityID
+	}
+	switch account.ChainName() {
+	case model.ChainBitcoin:
+		result["address_type"] = account.AddressType
+		result["network"] = account.Network
+	case model.ChainCosmos:
+		result["bech32_prefix"] = account.Prefix
+	case model.ChainTron:
+		if hexAddress, err := model.TronHexAddress(account.Address); err == nil {
+			result["hex_address"] = hexAddress
+		}
+	case model.ChainXRPL:
+		// the SigningPubKey clients set before encoding transactions
+		result["public_key"] = strings.ToUpper(account.PublicKey)
+	}
+	return result
+}
+
 func (b *PluginBackend) readDerivationPath(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 
-	account, err := model.ReadAccount(ctx, req)
+	account, err := readAllowedAccount(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -230,35 +534,37 @@ func (b *PluginBackend) signTransaction(ctx context.Context, req *logical.Reques
 		return nil, err
 	}
 
-	gasLimit, err := dataWrapper.MustGetUint64("gas_limit")
+	gasPrice, err := dataWrapper.MustGetBigInt("gas_price")
 	if err != nil {
 		return nil, err
 	}
 
-	gasPrice, err := dataWrapper.MustGetBigInt("gas_price")
+	account, err := readEthereumAccount(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	chainID, err := dataWrapper.MustGetBigInt("chainID")
+	profile, err := chainProfile(ctx, req, dataWrapper.GetString("chain", ""), account)
 	if err != nil {
 		return nil, err
 	}
 
-	account, err := model.ReadAccount(ctx, req)
+	chainID, err := evmChainID(dataWrapper, profile)
 	if err != nil {
-		return nil, fmt.Errorf("account %s is not existed", req.Path)
+		return nil, err
 	}
 
-	privateKey, err := crypto.HexToECDSA(account.PrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("error reconstructing private key")
+	var gasLimit uint64 = model.DefaultGasLimit
+	if _, ok := data.GetOk("gas_limit"); ok {
+		gasLimit, err = dataWrapper.MustGetUint64("gas_limit")
+		if err != nil {
+			return nil, err
+		}
+	} else if profile != nil && profile.DefaultGasLimit != 0 {
+		gasLimit = profile.DefaultGasLimit
 	}
-	defer utils.ZeroKey(privateKey)
 
-	var tx *types.Transaction
 	var txDataToSign []byte
-
 	if inputData != "" {
 		txDataToSign, err = hexutil.Decode(inputData)
 		if err != nil {
@@ -266,14 +572,147 @@ func (b *PluginBackend) signTransaction(ctx context.Context, req *logical.Reques
 		}
 	}
 
-	if addressToStr == "" {
-		tx = types.NewContractCreation(nonce, amount, gasLimit, gasPrice, txDataToSign)
+	var addressTo *common.Address
+	if addressToStr != "" {
+		address := common.HexToAddress(addressToStr)
+		addressTo = &address
+	}
+
+	return b.signEthereumTransaction(ctx, req, data, account, &transactionRequest{
+		AddressTo:         addressTo,
+		Amount:            amount,
+		Nonce:             nonce,
+		GasLimit:          gasLimit,
+		GasPrice:          gasPrice,
+		ChainID:           chainID,
+		Data:              txDataToSign,
+		Profile:           profile,
+		OverrideFeeLimits: dataWrapper.GetBool("override_fee_limits", false),
+	})
+}
+
+// readEthereumAccount reads the account of the request and checks it holds an Ethereum key
+func readEthereumAccount(ctx context.Context, req *logical.Request) (*model.Account, error) {
+	return readChainAccount(ctx, req, model.ChainEthereum)
+}
+
+// readChainAccount reads the account of the request and checks it holds a key
+// of the chain, which the mount config allows, and the requester may sign with it
+func readChainAccount(ctx context.Context, req *logical.Request, chain string) (*model.Account, error) {
+	account, err := readAllowedAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.ChainName() != chain {
+		return nil, fmt.Errorf("account %s is a %s account", req.Path, account.ChainName())
+	}
+
+	if !account.AllowsEntity(req.EntityID) {
+		return nil, fmt.Errorf("account %s is bound to another entity", req.Path)
+	}
+
+	return account, nil
+}
+
+// readAllowedAccount reads the account of the request and checks the mount config allows its chain
+func readAllowedAccount(ctx context.Context, req *logical.Request) (*model.Account, error) {
+	account, err := model.ReadAccount(ctx, req)
+	if err != nil || account == nil {
+		return nil, fmt.Errorf("account %s is not existed", req.Path)
+	}
+
+	config, err := model.ReadConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if !config.AllowsChain(account.ChainName()) {
+		return nil, fmt.Errorf("%s accounts are not allowed by the mount config", account.ChainName())
+	}
+
+	return account, nil
+}
+
+// transactionRequest holds the fields of an Ethereum transaction to be signed
+type transactionRequest struct {
+	AddressTo *common.Address
+	Amount    *big.Int
+	Nonce     uint64
+	GasLimit  uint64
+	GasPrice  *big.Int
+	ChainID   *big.Int
+	Data      []byte
+
+	// Profile is the chain profile of the request, linking the transaction to its explorer
+	Profile *model.ChainProfile
+
+	OverrideFeeLimits bool
+}
+
+// signEthereumTransaction checks the transaction against the signing policy of the account
+// and the role of the request, signs it and records it in the signing history.
+func (b *PluginBackend) signEthereumTransaction(ctx context.Context, req *logical.Request, data *framework.FieldData, account *model.Account, txRequest *transactionRequest) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	var tx *types.Transaction
+	var decodedCall *model.DecodedCall
+	var calldataWarning string
+	var err error
+
+	feeWarning, err := b.checkFeeLimits(ctx, req, txRequest.ChainID, txRequest.GasLimit, txRequest.GasPrice, nil, txRequest.Amount, txRequest.OverrideFeeLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	addressToStr := ""
+	if txRequest.AddressTo == nil {
+		if account.RequireKnownCalldata && len(txRequest.Data) > 0 {
+			return nil, errors.New("contract creation is not allowed when known calldata is required")
+		}
+		tx = types.NewContractCreation(txRequest.Nonce, txRequest.Amount, txRequest.GasLimit, txRequest.GasPrice, txRequest.Data)
 	} else {
-		addressTo := common.HexToAddress(addressToStr)
-		tx = types.NewTransaction(nonce, addressTo, amount, gasLimit, gasPrice, txDataToSign)
+		addressToStr = txRequest.AddressTo.Hex()
+		tx = types.NewTransaction(txRequest.Nonce, *txRequest.AddressTo, txRequest.Amount, txRequest.GasLimit, txRequest.GasPrice, txRequest.Data)
+
+		decodedCall, calldataWarning, err = b.decodeCalldata(ctx, req, account, txRequest.ChainID, *txRequest.AddressTo, txRequest.Data)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
+	signer := types.NewEIP155Signer(txRequest.ChainID)
+	historyEntry := &model.HistoryEntry{
+		Type:    model.SignatureTypeTransaction,
+		ChainID: txRequest.ChainID.String(),
+		To:      addressToStr,
+		Value:   txRequest.Amount.String(),
+		Digest:  signer.Hash(tx).Hex(),
+	}
+	// a token transfer or approval is recorded and checked against roles by its beneficiary
+	if txRequest.AddressTo != nil {
+		beneficiary, amount, token := model.CallTransfer(*txRequest.AddressTo, txRequest.Amount, txRequest.Data)
+		if token {
+			historyEntry.To, historyEntry.Value, historyEntry.Contract = beneficiary.Hex(), amount.String(), addressToStr
+		}
+	}
+	err = b.checkRole(ctx, req, data, name, historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.HexToECDSA(account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing private key")
+	}
+	defer utils.ZeroKey(privateKey)
+
+	signedTx, err := types.SignTx(tx, signer, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	historyEntry.TxHash = signedTx.Hash().Hex()
+	err = b.recordSignature(ctx, req, name, historyEntry)
 	if err != nil {
 		return nil, err
 	}
@@ -281,15 +720,106 @@ func (b *PluginBackend) signTransaction(ctx context.Context, req *logical.Reques
 	ts := types.Transactions{signedTx}
 	rawTxBytes := ts.GetRlp(0)
 	rawTxHex := hex.EncodeToString(rawTxBytes)
+	maxFee := model.MaxTransactionFee(txRequest.GasLimit, txRequest.GasPrice)
 
-	return &logical.Response{
+	resp := &logical.Response{
 		Data: map[string]interface{}{
 			"transaction_hash":   signedTx.Hash().Hex(),
 			"address_from":       account.Address,
 			"address_to":         addressToStr,
 			"signed_transaction": rawTxHex,
+			"max_fee":            maxFee.String(),
+			"max_fee_eth":        utils.FormatDecimalAmount(maxFee, 18),
 		},
-	}, nil
+	}
+	if decodedCall != nil {
+		resp.Data["decoded_call"] = decodedCall
+	}
+	if txRequest.Profile != nil && txRequest.Profile.ChainID == txRequest.ChainID.String() {
+		if link := txRequest.Profile.ExplorerLink(signedTx.Hash().Hex()); link != "" {
+			resp.Data["explorer_url"] = link
+		}
+	}
+	if calldataWarning != "" {
+		resp.AddWarning(calldataWarning)
+	}
+	if feeWarning != "" {
+		resp.AddWarning(feeWarning)
+	}
+
+	return resp, nil
+}
+
+// checkFeeLimits checks the fee against the fee limits of the chain.
+// A violation is returned as a warning instead when the caller overrides the limits.
+func (b *PluginBackend) checkFeeLimits(ctx context.Context, req *logical.Request, chainID *big.Int, gasLimit uint64, gasPrice *big.Int, priorityFee *big.Int, value *big.Int, override bool) (string, error) {
+	limits, err := model.ReadFeeLimits(ctx, req.Storage, chainID)
+	if err != nil {
+		return "", err
+	}
+	if limits == nil {
+		// the default fee bounds of a chain profile apply unless the chain ID has its own limits
+		profile, err := model.FindChainFeeLimits(ctx, req.Storage, chainID.String(), "")
+		if err != nil {
+			return "", err
+		}
+		if profile != nil {
+			limits = profile.FeeLimits
+		} else {
+			// chains without limits are still bounded by the default maximum gas price
+			limits = &model.FeeLimits{ChainID: chainID.String()}
+		}
+	}
+
+	err = limits.Check(gasLimit, gasPrice, priorityFee, value)
+	if err == nil {
+		return "", nil
+	}
+
+	// the signer cannot lift the limits alone, the mount config must allow it
+	config, configErr := model.ReadConfig(ctx, req.Storage)
+	if configErr != nil {
+		return "", configErr
+	}
+	if !config.AllowFeeLimitOverride {
+		return "", err
+	}
+	if !override {
+		return "", fmt.Errorf("%v, set override_fee_limits to sign anyway", err)
+	}
+
+	return fmt.Sprintf("fee limits overridden: %v", err), nil
+}
+
+// decodeCalldata decodes the calldata of a transaction against the registered contract ABIs.
+// It returns nil when the target is not registered, unless the account requires known calldata,
+// and a warning when the calldata does not match the ABI of the registered target.
+func (b *PluginBackend) decodeCalldata(ctx context.Context, req *logical.Request, account *model.Account, chainID *big.Int, addressTo common.Address, calldata []byte) (*model.DecodedCall, string, error) {
+	if len(calldata) == 0 {
+		return nil, "", nil
+	}
+
+	contract, err := model.FindContract(ctx, req.Storage, chainID, addressTo)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if contract == nil {
+		if account.RequireKnownCalldata {
+			return nil, "", fmt.Errorf("contract %s on chain %s is not registered", addressTo.Hex(), chainID)
+		}
+		return nil, "", nil
+	}
+
+	decodedCall, err := contract.DecodeCalldata(calldata)
+	if err != nil {
+		if account.RequireKnownCalldata {
+			return nil, "", utils.ErrorHandler("calldata", err)
+		}
+		return nil, fmt.Sprintf("calldata does not match the registered ABI of %s (%s): %v", contract.Name, addressTo.Hex(), err), nil
+	}
+
+	return decodedCall, "", nil
 }
 
 func (b *PluginBackend) signData(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
@@ -300,7 +830,17 @@ func (b *PluginBackend) signData(ctx context.Context, req *logical.Request, data
 		return nil, err
 	}
 
-	account, err := model.ReadAccount(ctx, req)
+	account, err := readEthereumAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	dataHash := crypto.Keccak256Hash([]byte(inputData))
+	historyEntry := &model.HistoryEntry{
+		Type:   model.SignatureTypeData,
+		Digest: dataHash.Hex(),
+	}
+	err = b.checkRole(ctx, req, data, data.Get("name").(string), historyEntry)
 	if err != nil {
 		return nil, err
 	}
@@ -311,13 +851,16 @@ func (b *PluginBackend) signData(ctx context.Context, req *logical.Request, data
 	}
 	defer utils.ZeroKey(privateKey)
 
-	dataHash := crypto.Keccak256Hash([]byte(inputData))
-
 	signature, err := crypto.Sign(dataHash.Bytes(), privateKey)
 	if err != nil {
 		return nil, err
 	}
 
+	err = b.recordSignature(ctx, req, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
 	hexSig := hexutil.Encode(signature)
 
 	return &logical.Response{
@@ -0,0 +1,211 @@
+package path
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// BitcoinMessagePaths returns the paths of Bitcoin message signing
+func BitcoinMessagePaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-message",
+			HelpSynopsis:    "sign a Bitcoin message",
+			HelpDescription: `prove control of the address of a bitcoin account with a BIP-137 or BIP-322 simple signature`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"message": {
+					Type:        framework.TypeString,
+					Description: "The message to sign.",
+				},
+				"format": {
+					Type:        framework.TypeString,
+					Description: "bip137 or bip322 - defaults to bip322 for p2wpkh and p2tr addresses and bip137 otherwise.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signBitcoinMessage,
+					Summary:  "sign a Bitcoin message",
+				},
+			},
+		},
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/verify-message",
+			HelpSynopsis:    "verify a Bitcoin message signature",
+			HelpDescription: `verify a BIP-137 or BIP-322 simple signature of a message against the address of a bitcoin account`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"message": {
+					Type:        framework.TypeString,
+					Description: "The signed message.",
+				},
+				"signature": {
+					Type:        framework.TypeString,
+					Description: "The base64 encoded signature.",
+				},
+				"format": {
+					Type:        framework.TypeString,
+					Description: "bip137 or bip322 - detected from the signature if empty.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.verifyBitcoinMessage,
+					Summary:  "verify a Bitcoin message signature",
+				},
+			},
+		},
+	}
+}
+
+// messageFormat returns the requested signature format, defaulting to
+// BIP-322 for native segwit and taproot addresses
+func messageFormat(account *model.Account, format string) (string, error) {
+	switch format {
+	case "":
+		if account.AddressType == model.AddressTypeP2WPKH || account.AddressType == model.AddressTypeP2TR {
+			return model.MessageFormatBIP322, nil
+		}
+		return model.MessageFormatBIP137, nil
+	case model.MessageFormatBIP137, model.MessageFormatBIP322:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported format %s", format)
+}
+
+func (b *PluginBackend) signBitcoinMessage(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	message, err := dataWrapper.MustGetString("message")
+	if err != nil {
+		return nil, utils.ErrorHandler("message", err)
+	}
+
+	account, err := readBitcoinAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := messageFormat(account, dataWrapper.GetString("format", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	digest := model.BitcoinMessageHash(message)
+	if format == model.MessageFormatBIP322 {
+		digest = model.TaggedHash("BIP0322-signed-message", []byte(message))
+	}
+	historyEntry := &model.HistoryEntry{
+		Type:    model.SignatureTypeBitcoinMessage,
+		ChainID: account.Network,
+		Digest:  hex.EncodeToString(digest),
+	}
+	err = b.checkRole(ctx, req, data, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := bitcoinPrivateKey(account)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.ZeroKey(privateKey.ToECDSA())
+
+	var signature string
+	if format == model.MessageFormatBIP137 {
+		signature, err = model.SignBIP137Message(privateKey, account.AddressType, message)
+	} else {
+		var pkScript []byte
+		pkScript, err = model.PayToAddressScript(account)
+		if err != nil {
+			return nil, err
+		}
+		signature, err = model.SignBIP322Message(privateKey, pkScript, message)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.recordSignature(ctx, req, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"address":   account.Address,
+			"format":    format,
+			"signature": signature,
+		},
+	}, nil
+}
+
+func (b *PluginBackend) verifyBitcoinMessage(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	message := dataWrapper.GetString("message", "")
+	signature, err := dataWrapper.MustGetString("signature")
+	if err != nil {
+		return nil, utils.ErrorHandler("signature", err)
+	}
+
+	account, err := readBitcoinAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	format := dataWrapper.GetString("format", "")
+	if format == "" {
+		// a BIP-137 signature is a 65 bytes compact signature, a BIP-322 one a witness stack
+		format = model.MessageFormatBIP322
+		if decoded, err := base64.StdEncoding.DecodeString(signature); err == nil && len(decoded) == 65 && decoded[0] >= 27 && decoded[0] <= 42 {
+			format = model.MessageFormatBIP137
+		}
+	}
+
+	var valid bool
+	switch format {
+	case model.MessageFormatBIP137:
+		params, err := model.BitcoinNetworkParams(account.Network)
+		if err != nil {
+			return nil, err
+		}
+		valid, err = model.VerifyBIP137Message(account.Address, account.AddressType, params, message, signature)
+		if err != nil {
+			return nil, err
+		}
+	case model.MessageFormatBIP322:
+		pkScript, err := model.PayToAddressScript(account)
+		if err != nil {
+			return nil, err
+		}
+		valid, err = model.VerifyBIP322Message(pkScript, message, signature)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %s", format)
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"address": account.Address,
+			"format":  format,
+			"valid":   valid,
+		},
+	}, nil
+}
@@ -0,0 +1,436 @@
+package path
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// CeremonyPaths returns the paths of the multi-operator key ceremony
+func CeremonyPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "ceremony",
+			HelpSynopsis:    "start a key ceremony",
+			HelpDescription: `start a ceremony creating the wallet from the contributions of several operators, so that no single operator controls the seed`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"mode": {
+					Type:        framework.TypeString,
+					Default:     model.CeremonyGenerate,
+					Description: "generate a new seed from entropy contributions, or restore it from SLIP-39 share contributions.",
+				},
+				"threshold": {
+					Type:        framework.TypeInt,
+					Description: "The number of operators contributing entropy to a generated seed, or the minimum number of operators contributing shares to a restored seed, at least 2.",
+				},
+				"backup_threshold": {
+					Type:        framework.TypeInt,
+					Description: "The number of backup shares recovering a generated seed, at least 2 - defaults to the threshold.",
+				},
+				"share_passphrase": {
+					Type:         framework.TypeString,
+					Default:      "",
+					Description:  "The passphrase of the backup shares, or of the contributed shares when restoring.",
+					DisplayAttrs: sensitive,
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.startCeremony,
+					Summary:  "start a key ceremony",
+				},
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readCeremony,
+					Summary:  "read the progress and participants of the key ceremony",
+				},
+				logical.DeleteOperation: &framework.PathOperation{
+					Callback: b.deleteCeremony,
+					Summary:  "abort the key ceremony",
+				},
+			},
+		},
+		{
+			Pattern:         "ceremony/contribute",
+			HelpSynopsis:    "contribute to the key ceremony",
+			HelpDescription: `contribute entropy, or a SLIP-39 share when restoring, to the key ceremony. The wallet is created once enough operators contributed.`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"entropy": {
+					Type:         framework.TypeString,
+					Description:  "Hex encoded random bytes, at least 16, when generating a seed.",
+					DisplayAttrs: sensitive,
+				},
+				"share": {
+					Type:         framework.TypeString,
+					Description:  "The SLIP-39 share of the operator when restoring a seed.",
+					DisplayAttrs: sensitive,
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.contributeCeremony,
+					Summary:  "contribute to the key ceremony",
+				},
+			},
+		},
+		{
+			Pattern:         "ceremony/share",
+			HelpSynopsis:    "deliver the own backup share",
+			HelpDescription: `deliver once, through response wrapping, the backup share of the generated seed assigned to the requesting operator`,
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readCeremonyShare,
+					Summary:  "deliver the own backup share",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) startCeremony(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	b.ceremonyLock.Lock()
+	defer b.ceremonyLock.Unlock()
+
+	ceremony, err := model.ReadCeremony(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony != nil && !ceremony.Completed() {
+		return nil, errors.New("a key ceremony is in progress, delete it before starting another")
+	}
+	err = refuseExistingWallet(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ceremony = &model.Ceremony{
+		Mode:            data.Get("mode").(string),
+		Threshold:       data.Get("threshold").(int),
+		SharePassphrase: data.Get("share_passphrase").(string),
+		Participants:    []model.CeremonyParticipant{},
+		StartedAt:       time.Now(),
+	}
+	if ceremony.Mode == model.CeremonyGenerate {
+		config, err := model.ReadConfig(ctx, req.Storage)
+		if err != nil {
+			return nil, err
+		}
+		if !config.ReturnMnemonic {
+			return nil, errors.New("the backup shares of generated seeds are disabled by the mount config")
+		}
+
+		ceremony.BackupThreshold = data.Get("backup_threshold").(int)
+		if ceremony.BackupThreshold == 0 {
+			ceremony.BackupThreshold = ceremony.Threshold
+		}
+
+		entropy := make([]byte, 32)
+		_, err = rand.Read(entropy)
+		if err != nil {
+			return nil, err
+		}
+		ceremony.Entropy = hex.EncodeToString(entropy)
+	}
+
+	err = ceremony.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = putCeremony(ctx, req, ceremony)
+	if err != nil {
+		return nil, err
+	}
+
+	return ceremonyResponse(ceremony), nil
+}
+
+func (b *PluginBackend) contributeCeremony(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	if req.EntityID == "" {
+		return nil, errors.New("ceremony contributions require a request from an identity entity")
+	}
+
+	b.ceremonyLock.Lock()
+	defer b.ceremonyLock.Unlock()
+
+	ceremony, err := model.ReadCeremony(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony == nil {
+		return nil, errors.New("no key ceremony was started")
+	}
+	if ceremony.Completed() {
+		return nil, errors.New("the key ceremony is completed")
+	}
+	if ceremony.Participant(req.EntityID) != nil {
+		return nil, fmt.Errorf("entity %s already contributed to the key ceremony", req.EntityID)
+	}
+
+	entropy := data.Get("entropy").(string)
+	share := data.Get("share").(string)
+	switch ceremony.Mode {
+	case model.CeremonyGenerate:
+		if share != "" {
+			return nil, errors.New("the key ceremony generates a seed and expects entropy, not a share")
+		}
+		entropyBytes, err := hex.DecodeString(entropy)
+		if err != nil || len(entropyBytes) < model.MinCeremonyEntropyBytes {
+			return nil, fmt.Errorf("entropy must be at least %d hex encoded bytes", model.MinCeremonyEntropyBytes)
+		}
+		ceremony.Contributions = append(ceremony.Contributions, entropy)
+	case model.CeremonyRestore:
+		if entropy != "" {
+			return nil, errors.New("the key ceremony restores a seed and expects a share, not entropy")
+		}
+		_, err = model.DecodeSLIP39Share(share)
+		if err != nil {
+			return nil, err
+		}
+		ceremony.Contributions = append(ceremony.Contributions, share)
+	}
+
+	ceremony.Participants = append(ceremony.Participants, model.CeremonyParticipant{
+		EntityID:      req.EntityID,
+		DisplayName:   req.DisplayName,
+		ContributedAt: time.Now(),
+	})
+
+	switch ceremony.Mode {
+	case model.CeremonyGenerate:
+		if len(ceremony.Contributions) >= ceremony.Threshold {
+			err = b.generateCeremonyWallet(ctx, req, ceremony)
+		}
+	case model.CeremonyRestore:
+		if len(ceremony.Participants) >= ceremony.Threshold {
+			err = b.restoreCeremonyWallet(ctx, req, ceremony)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = putCeremony(ctx, req, ceremony)
+	if err != nil {
+		return nil, err
+	}
+
+	return ceremonyResponse(ceremony), nil
+}
+
+// generateCeremonyWallet creates the wallet from the combined entropy and
+// assigns a backup share of its seed to every participant
+func (b *PluginBackend) generateCeremonyWallet(ctx context.Context, req *logical.Request, ceremony *model.Ceremony) error {
+	err := refuseExistingWallet(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	config, err := model.ReadConfig(ctx, req.Storage)
+	if err != nil {
+		return err
+	}
+
+	entropy, err := ceremony.CombineEntropy(config.EntropyBits)
+	if err != nil {
+		return err
+	}
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return err
+	}
+
+	wallet, err := model.NewWalletFromMnemonic(mnemonic, "")
+	if err != nil {
+		return err
+	}
+	wallet.Source = model.WalletSourceCeremony
+
+	seed, err := hex.DecodeString(wallet.Seed)
+	if err != nil {
+		return errors.New("Fail to decode the wallet seed")
+	}
+
+	groups := []model.SLIP39Group{{Threshold: ceremony.BackupThreshold, Count: len(ceremony.Participants)}}
+	shares, err := model.SplitSLIP39(seed, ceremony.SharePassphrase, 1, groups, model.DefaultSLIP39IterationExponent)
+	if err != nil {
+		return err
+	}
+
+	err = putWallet(ctx, req, wallet)
+	if err != nil {
+		return err
+	}
+
+	resp, err := b.storeSLIP39Shares(ctx, req, model.CeremonySharesPrefix, shares)
+	if err != nil {
+		return err
+	}
+	for i, name := range resp.Data["shares"].([]string) {
+		ceremony.Participants[i].Share = name
+	}
+
+	ceremony.Complete()
+	return nil
+}
+
+// restoreCeremonyWallet creates the wallet once the contributed shares recover the seed
+func (b *PluginBackend) restoreCeremonyWallet(ctx context.Context, req *logical.Request, ceremony *model.Ceremony) error {
+	err := refuseExistingWallet(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	seed, err := model.CombineSLIP39(ceremony.Contributions, ceremony.SharePassphrase)
+	if errors.Is(err, model.ErrInsufficientSLIP39Shares) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	wallet, err := model.NewWalletFromSeed(seed)
+	if err != nil {
+		return err
+	}
+	wallet.Source = model.WalletSourceSLIP39
+
+	err = putWallet(ctx, req, wallet)
+	if err != nil {
+		return err
+	}
+
+	err = clearSLIP39Shares(ctx, req, model.SLIP39SharesPrefix)
+	if err != nil {
+		return err
+	}
+
+	ceremony.Complete()
+	return nil
+}
+
+func (b *PluginBackend) readCeremony(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	ceremony, err := model.ReadCeremony(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony == nil {
+		return nil, nil
+	}
+
+	return ceremonyResponse(ceremony), nil
+}
+
+func (b *PluginBackend) deleteCeremony(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	b.ceremonyLock.Lock()
+	defer b.ceremonyLock.Unlock()
+
+	err := req.Storage.Delete(ctx, model.CeremonyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// the backup shares are only delivered to the participants the ceremony records
+	err = clearSLIP39Shares(ctx, req, model.CeremonySharesPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *PluginBackend) readCeremonyShare(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	b.ceremonyLock.Lock()
+	defer b.ceremonyLock.Unlock()
+
+	ceremony, err := model.ReadCeremony(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony == nil {
+		return nil, errors.New("no key ceremony was started")
+	}
+
+	participant := ceremony.Participant(req.EntityID)
+	if req.EntityID == "" || participant == nil {
+		return nil, errors.New("the requesting entity did not contribute to the key ceremony")
+	}
+	if participant.Share == "" {
+		return nil, errors.New("no backup share awaits delivery to the requesting entity")
+	}
+
+	resp, err := deliverSLIP39Share(ctx, req, model.CeremonySharesPrefix, participant.Share)
+	if err != nil {
+		return nil, err
+	}
+
+	participant.Share = ""
+	err = putCeremony(ctx, req, ceremony)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// refuseExistingWallet refuses ceremonies which would replace the wallet
+func refuseExistingWallet(ctx context.Context, req *logical.Request) error {
+	exists, err := model.WalletExists(ctx, req.Storage)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("a wallet already exists, key ceremonies only create the wallet of a mount without one")
+	}
+	return nil
+}
+
+func putCeremony(ctx context.Context, req *logical.Request, ceremony *model.Ceremony) error {
+	entry, err := logical.StorageEntryJSON(model.CeremonyPath, ceremony)
+	if err != nil {
+		return err
+	}
+
+	return req.Storage.Put(ctx, entry)
+}
+
+func ceremonyResponse(ceremony *model.Ceremony) *logical.Response {
+	participants := []map[string]interface{}{}
+	for _, participant := range ceremony.Participants {
+		participants = append(participants, map[string]interface{}{
+			"entity_id":      participant.EntityID,
+			"display_name":   participant.DisplayName,
+			"contributed_at": participant.ContributedAt,
+			"share_pending":  participant.Share != "",
+		})
+	}
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"mode":         ceremony.Mode,
+			"participants": participants,
+			"started_at":   ceremony.StartedAt,
+			"completed":    ceremony.Completed(),
+		},
+	}
+	resp.Data["threshold"] = ceremony.Threshold
+	if ceremony.Mode == model.CeremonyGenerate {
+		resp.Data["backup_threshold"] = ceremony.BackupThreshold
+	}
+	if ceremony.Completed() {
+		resp.Data["completed_at"] = *ceremony.CompletedAt
+	}
+
+	return resp
+}
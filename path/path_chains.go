@@ -0,0 +1,298 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// ChainPaths returns the paths of the chain profiles
+func ChainPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "chains/?",
+			HelpSynopsis:    "list chain profiles",
+			HelpDescription: `list the names of the configured chain profiles`,
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{
+					Callback: b.listChainProfiles,
+					Summary:  "list chain profiles",
+				},
+			},
+		},
+		{
+			Pattern:         "chains/" + framework.GenericNameRegex("name"),
+			HelpSynopsis:    "configure a chain profile",
+			HelpDescription: `configure the chain ID, family, coin type, address format, default fee bounds and explorer of a chain referenced by name on account creation and signing`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type:        framework.TypeString,
+					Description: "The name of the chain, other than the built-in chains ethereum, bitcoin, solana, cosmos, tron and xrpl.",
+				},
+				"family": {
+					Type:        framework.TypeString,
+					Description: "The chain family, evm, bitcoin, solana, cosmos, tron or xrpl.",
+				},
+				"chain_id": {
+					Type:        framework.TypeString,
+					Description: "The chain ID of evm and cosmos chains, the network of bitcoin chains - defaults to mainnet.",
+				},
+				"coin_type": {
+					Type:        framework.TypeInt,
+					Description: "The SLIP-44 coin type of the default derivation path.",
+				},
+				"derivation_path": {
+					Type:        framework.TypeString,
+					Description: "The derivation path of accounts created without one - defaults to the path of the family and coin type.",
+				},
+				"address_format": {
+					Type:        framework.TypeString,
+					Description: "The address type of bitcoin chains - defaults to p2wpkh, the bech32 prefix of cosmos chains.",
+				},
+				"default_gas_limit": {
+					Type:        framework.TypeInt,
+					Description: "The gas limit of evm transactions signed without one - defaults to 21000.",
+				},
+				"min_gas_price": {
+					Type:        framework.TypeString,
+					Description: "The minimum gas price in wei, unless fee limits are configured for the chain ID.",
+				},
+				"max_gas_price": {
+					Type:        framework.TypeString,
+					Description: "The maximum gas price in wei, unless fee limits are configured for the chain ID.",
+				},
+				"max_priority_fee": {
+					Type:        framework.TypeString,
+					Description: "The maximum priority fee per gas in wei, unless fee limits are configured for the chain ID.",
+				},
+				"max_fee": {
+					Type:        framework.TypeString,
+					Description: "The maximum total fee in wei, unless fee limits are configured for the chain ID.",
+				},
+				"max_fee_to_value_ratio": {
+					Type:        framework.TypeString,
+					Description: "The maximum ratio of the total fee to the transferred value, unless fee limits are configured for the chain ID.",
+				},
+				"explorer_url": {
+					Type:        framework.TypeString,
+					Description: "The URL of transactions on the explorer, with {hash} standing for the transaction hash.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.writeChainProfile,
+					Summary:  "configure a chain profile",
+				},
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.writeChainProfile,
+					Summary:  "update a chain profile",
+				},
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readChainProfile,
+					Summary:  "read a chain profile",
+				},
+				logical.DeleteOperation: &framework.PathOperation{
+					Callback: b.deleteChainProfile,
+					Summary:  "remove a chain profile",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) listChainProfiles(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	names, err := req.Storage.List(ctx, model.ChainProfilesPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(names), nil
+}
+
+func (b *PluginBackend) writeChainProfile(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+	name := data.Get("name").(string)
+
+	profile, err := model.ReadChainProfile(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		family, err := dataWrapper.MustGetString("family")
+		if err != nil {
+			return nil, utils.ErrorHandler("family", err)
+		}
+		profile = &model.ChainProfile{Name: name, Family: family}
+	} else if family, ok := data.GetOk("family"); ok && family.(string) != profile.Family {
+		return nil, fmt.Errorf("the family of chain %s is %s and cannot be changed", name, profile.Family)
+	}
+
+	for field, value := range map[string]*string{
+		"chain_id":        &profile.ChainID,
+		"derivation_path": &profile.DerivationPath,
+		"address_format":  &profile.AddressFormat,
+		"explorer_url":    &profile.ExplorerURL,
+	} {
+		if _, ok := data.GetOk(field); ok {
+			*value = dataWrapper.GetString(field, "")
+		}
+	}
+	if profile.Family == model.FamilyBitcoin {
+		if profile.ChainID == "" {
+			profile.ChainID = model.NetworkMainnet
+		}
+		if profile.AddressFormat == "" {
+			profile.AddressFormat = model.AddressTypeP2WPKH
+		}
+	}
+
+	if coinType, ok := data.GetOk("coin_type"); ok {
+		if coinType.(int) < 0 {
+			return nil, fmt.Errorf("invalid coin_type %d", coinType.(int))
+		}
+		profile.CoinType = uint32(coinType.(int))
+	}
+	if gasLimit, ok := data.GetOk("default_gas_limit"); ok {
+		if gasLimit.(int) < 0 {
+			return nil, fmt.Errorf("invalid default_gas_limit %d", gasLimit.(int))
+		}
+		profile.DefaultGasLimit = uint64(gasLimit.(int))
+	}
+
+	limits := profile.FeeLimits
+	if limits == nil {
+		limits = &model.FeeLimits{}
+	}
+	for field, value := range map[string]*string{
+		"min_gas_price":          &limits.MinGasPrice,
+		"max_gas_price":          &limits.MaxGasPrice,
+		"max_priority_fee":       &limits.MaxPriorityFee,
+		"max_fee":                &limits.MaxFee,
+		"max_fee_to_value_ratio": &limits.MaxFeeToValueRatio,
+	} {
+		if _, ok := data.GetOk(field); ok {
+			*value = dataWrapper.GetString(field, "")
+		}
+	}
+	limits.ChainID = profile.ChainID
+	profile.FeeLimits = limits
+	if *limits == (model.FeeLimits{ChainID: profile.ChainID}) {
+		profile.FeeLimits = nil
+	}
+
+	err = profile.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// the default fee bounds of a chain ID come from a single profile
+	if profile.FeeLimits != nil {
+		other, err := model.FindChainFeeLimits(ctx, req.Storage, profile.ChainID, name)
+		if err != nil {
+			return nil, err
+		}
+		if other != nil {
+			return nil, fmt.Errorf("chain %s already holds the fee bounds of chain ID %s", other.Name, profile.ChainID)
+		}
+	}
+
+	entry, err := logical.StorageEntryJSON(model.ChainProfilesPrefix+name, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	err = req.Storage.Put(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainProfileResponse(profile), nil
+}
+
+func (b *PluginBackend) readChainProfile(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	profile, err := model.ReadChainProfile(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, nil
+	}
+
+	return chainProfileResponse(profile), nil
+}
+
+func (b *PluginBackend) deleteChainProfile(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	err := req.Storage.Delete(ctx, model.ChainProfilesPrefix+data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func chainProfileResponse(profile *model.ChainProfile) *logical.Response {
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"name":              profile.Name,
+			"family":            profile.Family,
+			"chain_id":          profile.ChainID,
+			"coin_type":         profile.CoinType,
+			"derivation_path":   profile.DerivationPath,
+			"address_format":    profile.AddressFormat,
+			"default_gas_limit": profile.DefaultGasLimit,
+			"explorer_url":      profile.ExplorerURL,
+		},
+	}
+	if profile.FeeLimits != nil {
+		resp.Data["min_gas_price"] = profile.FeeLimits.MinGasPrice
+		resp.Data["max_gas_price"] = profile.FeeLimits.MaxGasPrice
+		resp.Data["max_priority_fee"] = profile.FeeLimits.MaxPriorityFee
+		resp.Data["max_fee"] = profile.FeeLimits.MaxFee
+		resp.Data["max_fee_to_value_ratio"] = profile.FeeLimits.MaxFeeToValueRatio
+	}
+
+	return resp
+}
+
+// chainProfile returns the chain profile named by the request, or else the one
+// the account was created for. It returns nil when neither names a profile.
+func chainProfile(ctx context.Context, req *logical.Request, name string, account *model.Account) (*model.ChainProfile, error) {
+	if name == "" {
+		if account.Profile == "" {
+			return nil, nil
+		}
+		// a removed profile leaves its accounts usable with explicit parameters
+		return model.ReadChainProfile(ctx, req.Storage, account.Profile)
+	}
+
+	if model.IsBuiltinChain(name) {
+		return nil, fmt.Errorf("%s is a built-in chain, not a chain profile", name)
+	}
+
+	profile, err := model.ReadChainProfile(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("chain %s is not configured", name)
+	}
+	if profile.Chain() != account.ChainName() {
+		return nil, fmt.Errorf("chain %s is a %s chain, not usable by the %s account %s", name, profile.Family, account.ChainName(), account.Address)
+	}
+
+	return profile, nil
+}
+
+// evmChainID returns the chainID of the request, or else the chain ID of the profile
+func evmChainID(dataWrapper *utils.FieldDataWrapper, profile *model.ChainProfile) (*big.Int, error) {
+	if _, ok := dataWrapper.GetOk("chainID"); ok || profile == nil {
+		return dataWrapper.MustGetBigInt("chainID")
+	}
+	return profile.EVMChainID()
+}
@@ -0,0 +1,147 @@
+package path
+
+import (
+	"context"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// ConfigPaths returns the path of the mount config
+func ConfigPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "config",
+			HelpSynopsis:    "configure the plugin defaults of the mount",
+			HelpDescription: `configure the mnemonic entropy, default derivation prefix, allowed chains, whether mnemonics are returned, whether signatures require a role and whether fee limits may be overridden`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"entropy_bits": {
+					Type:        framework.TypeInt,
+					Description: "The entropy of generated mnemonics, 128 to 256 bits by steps of 32 - defaults to 256.",
+				},
+				"default_derivation_prefix": {
+					Type:        framework.TypeString,
+					Description: "The path relative derivation paths of accounts are appended to - defaults to m/44'/60'/0'/0.",
+				},
+				"allowed_chains": {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "The chains accounts may be created and used for. Empty allows every chain.",
+				},
+				"return_mnemonic": {
+					Type:        framework.TypeBool,
+					Description: "Return generated mnemonics and allow reading the wallet - defaults to true.",
+				},
+				"require_role": {
+					Type:        framework.TypeBool,
+					Description: "Refuse signatures requested outside roles/<role>/ - defaults to false.",
+				},
+				"allow_fee_limit_override": {
+					Type:        framework.TypeBool,
+					Description: "Let sign requests set override_fee_limits to sign fees violating the fee limits - defaults to false.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.writeConfig,
+					Summary:  "configure the mount",
+				},
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.writeConfig,
+					Summary:  "update the mount config",
+				},
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readConfig,
+					Summary:  "read the mount config",
+				},
+				logical.DeleteOperation: &framework.PathOperation{
+					Callback: b.deleteConfig,
+					Summary:  "reset the mount config to the defaults",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) writeConfig(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	config, err := model.ReadConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if entropyBits, ok := data.GetOk("entropy_bits"); ok {
+		config.EntropyBits = entropyBits.(int)
+	}
+	if _, ok := data.GetOk("default_derivation_prefix"); ok {
+		config.DefaultDerivationPrefix = dataWrapper.GetString("default_derivation_prefix", "")
+	}
+	if allowedChains, ok := data.GetOk("allowed_chains"); ok {
+		config.AllowedChains = allowedChains.([]string)
+	}
+	if _, ok := data.GetOk("return_mnemonic"); ok {
+		config.ReturnMnemonic = dataWrapper.GetBool("return_mnemonic", true)
+	}
+	if _, ok := data.GetOk("require_role"); ok {
+		config.RequireRole = dataWrapper.GetBool("require_role", false)
+	}
+	if _, ok := data.GetOk("allow_fee_limit_override"); ok {
+		config.AllowFeeLimitOverride = dataWrapper.GetBool("allow_fee_limit_override", false)
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := logical.StorageEntryJSON(model.ConfigPath, config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = req.Storage.Put(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return configResponse(config), nil
+}
+
+func (b *PluginBackend) readConfig(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := model.ReadConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	return configResponse(config), nil
+}
+
+func (b *PluginBackend) deleteConfig(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	err := req.Storage.Delete(ctx, model.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func configResponse(config *model.Config) *logical.Response {
+	allowedChains := config.AllowedChains
+	if allowedChains == nil {
+		allowedChains = []string{}
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"entropy_bits":              config.EntropyBits,
+			"default_derivation_prefix": config.DefaultDerivationPrefix,
+			"allowed_chains":            allowedChains,
+			"return_mnemonic":           config.ReturnMnemonic,
+			"require_role":              config.RequireRole,
+			"allow_fee_limit_override":  config.AllowFeeLimitOverride,
+		},
+	}
+}
@@ -0,0 +1,190 @@
+package path
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// ContractPaths returns the paths of the contract ABI registry
+func ContractPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "contracts/?",
+			HelpSynopsis:    "list registered contracts",
+			HelpDescription: `list registered contracts`,
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{
+					Callback: b.listContracts,
+					Summary:  "list registered contracts",
+				},
+			},
+		},
+		{
+			Pattern:         "contracts/" + framework.GenericNameRegex("name"),
+			HelpSynopsis:    "register a contract ABI",
+			HelpDescription: `register a contract ABI used to decode calldata before signing`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"address": {
+					Type:        framework.TypeString,
+					Description: "The address of the contract.",
+				},
+				"chainID": {
+					Type:        framework.TypeString,
+					Description: "The chain ID of the blockchain network the contract is deployed on.",
+				},
+				"abi": {
+					Type:        framework.TypeString,
+					Description: "The JSON ABI of the contract.",
+				},
+				"decimals": {
+					Type:        framework.TypeInt,
+					Description: "The decimals of an ERC-20 token contract.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.writeContract,
+					Summary:  "register a contract",
+				},
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.writeContract,
+					Summary:  "update a registered contract",
+				},
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readContract,
+					Summary:  "read a registered contract",
+				},
+				logical.DeleteOperation: &framework.PathOperation{
+					Callback: b.deleteContract,
+					Summary:  "remove a registered contract",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) listContracts(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	names, err := req.Storage.List(ctx, model.ContractPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(names), nil
+}
+
+func (b *PluginBackend) writeContract(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	name, err := dataWrapper.MustGetString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := dataWrapper.MustGetString("address")
+	if err != nil {
+		return nil, utils.ErrorHandler("address", err)
+	}
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid contract address %s", address)
+	}
+
+	chainID, err := dataWrapper.MustGetBigInt("chainID")
+	if err != nil {
+		return nil, utils.ErrorHandler("chainID", err)
+	}
+	if chainID == nil {
+		return nil, fmt.Errorf("invalid chain ID")
+	}
+
+	abiJSON := dataWrapper.GetString("abi", "")
+
+	contract := &model.Contract{
+		Name:    name,
+		Address: common.HexToAddress(address).Hex(),
+		ChainID: chainID.String(),
+		ABI:     abiJSON,
+	}
+
+	if decimalsRaw, ok := data.GetOk("decimals"); ok {
+		decimals := decimalsRaw.(int)
+		if decimals < 0 || decimals > 77 {
+			return nil, fmt.Errorf("invalid decimals %d", decimals)
+		}
+		contractDecimals := uint8(decimals)
+		contract.Decimals = &contractDecimals
+
+		// tokens may be registered by decimals alone
+		if contract.ABI == "" {
+			contract.ABI = model.ERC20ABI
+		}
+	}
+	if contract.ABI == "" {
+		return nil, utils.ErrorHandler("abi", errors.New("abi is required"))
+	}
+
+	if _, err := contract.ParseABI(); err != nil {
+		return nil, utils.ErrorHandler("abi", err)
+	}
+
+	entry, err := logical.StorageEntryJSON(model.ContractPrefix+name, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	err = req.Storage.Put(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"name":    contract.Name,
+			"address": contract.Address,
+			"chainID": contract.ChainID,
+		},
+	}, nil
+}
+
+func (b *PluginBackend) readContract(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	contract, err := model.ReadContract(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+	if contract == nil {
+		return nil, nil
+	}
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"name":    contract.Name,
+			"address": contract.Address,
+			"chainID": contract.ChainID,
+			"abi":     contract.ABI,
+		},
+	}
+	if contract.Decimals != nil {
+		resp.Data["decimals"] = *contract.Decimals
+	}
+
+	return resp, nil
+}
+
+func (b *PluginBackend) deleteContract(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	err := req.Storage.Delete(ctx, model.ContractPrefix+data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,152 @@
+package path
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// Cosmos SDK sign modes
+const (
+	cosmosSignModeDirect    = "direct"
+	cosmosSignModeAminoJSON = "amino_json"
+)
+
+// CosmosPaths returns the paths of Cosmos SDK signing
+func CosmosPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-cosmos",
+			HelpSynopsis:    "sign a Cosmos SDK sign document",
+			HelpDescription: `sign a SIGN_MODE_DIRECT SignDoc or a SIGN_MODE_LEGACY_AMINO_JSON StdSignDoc with a cosmos account`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"sign_mode": {
+					Type:        framework.TypeString,
+					Description: "direct or amino_json - defaults to direct.",
+					Default:     cosmosSignModeDirect,
+				},
+				"sign_doc": {
+					Type:        framework.TypeString,
+					Description: "The base64 encoded protobuf SignDoc for direct, the StdSignDoc JSON for amino_json.",
+				},
+				"chain_id": {
+					Type:        framework.TypeString,
+					Description: "The expected chain ID. The document is refused if its chain_id differs - defaults to the chain ID of the chain.",
+				},
+				"chain": {
+					Type:        framework.TypeString,
+					Description: "The name of the chain profile providing the expected chain ID - defaults to the chain the account was created for.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signCosmos,
+					Summary:  "sign a Cosmos SDK sign document",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) signCosmos(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	inputDoc, err := dataWrapper.MustGetString("sign_doc")
+	if err != nil {
+		return nil, utils.ErrorHandler("sign_doc", err)
+	}
+
+	signMode := dataWrapper.GetString("sign_mode", cosmosSignModeDirect)
+	var signBytes []byte
+	var doc *model.CosmosSignDoc
+	switch signMode {
+	case cosmosSignModeDirect:
+		signBytes, err = base64.StdEncoding.DecodeString(inputDoc)
+		if err != nil {
+			return nil, utils.ErrorHandler("sign_doc", err)
+		}
+		doc, err = model.ParseCosmosDirectSignDoc(signBytes)
+	case cosmosSignModeAminoJSON:
+		signBytes, doc, err = model.CanonicalAminoJSON(inputDoc)
+	default:
+		return nil, fmt.Errorf("unsupported sign_mode %s", signMode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := readChainAccount(ctx, req, model.ChainCosmos)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := dataWrapper.GetString("chain_id", "")
+	if chainID == "" {
+		profile, err := chainProfile(ctx, req, dataWrapper.GetString("chain", ""), account)
+		if err != nil {
+			return nil, err
+		}
+		if profile != nil {
+			chainID = profile.ChainID
+		}
+	}
+	if chainID != "" && chainID != doc.ChainID {
+		return nil, fmt.Errorf("sign doc is for chain %s, expected %s", doc.ChainID, chainID)
+	}
+
+	signHash := sha256.Sum256(signBytes)
+	historyEntry := &model.HistoryEntry{
+		Type:    model.SignatureTypeCosmos,
+		ChainID: doc.ChainID,
+		Digest:  hex.EncodeToString(signHash[:]),
+	}
+	err = b.checkRole(ctx, req, data, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKeyBytes, err := hex.DecodeString(account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing private key")
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), privateKeyBytes)
+	defer utils.ZeroKey(privateKey.ToECDSA())
+
+	signature, err := model.SignCosmos(privateKey, signBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.recordSignature(ctx, req, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"address":        account.Address,
+			"pub_key":        base64.StdEncoding.EncodeToString(privateKey.PubKey().SerializeCompressed()),
+			"signature":      base64.StdEncoding.EncodeToString(signature),
+			"sign_mode":      signMode,
+			"chain_id":       doc.ChainID,
+			"account_number": doc.AccountNumber,
+		},
+	}
+	if doc.Sequence != "" {
+		resp.Data["sequence"] = doc.Sequence
+	}
+
+	return resp, nil
+}
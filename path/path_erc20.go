@@ -0,0 +1,202 @@
+package path
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// ERC20Paths returns the paths of the ERC-20 token helpers
+func ERC20Paths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-erc20-transfer",
+			HelpSynopsis:    "sign an ERC-20 token transfer",
+			HelpDescription: `build the calldata of transfer(address,uint256) and sign the transaction to the token contract`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields:          erc20Fields("to", "The address receiving the tokens."),
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signERC20("to", model.PackERC20Transfer),
+					Summary:  "sign an ERC-20 token transfer",
+				},
+			},
+		},
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-erc20-approve",
+			HelpSynopsis:    "sign an ERC-20 token approval",
+			HelpDescription: `build the calldata of approve(address,uint256) and sign the transaction to the token contract`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields:          erc20Fields("spender", "The address allowed to spend the tokens."),
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signERC20("spender", model.PackERC20Approve),
+					Summary:  "sign an ERC-20 token approval",
+				},
+			},
+		},
+	}
+}
+
+func erc20Fields(counterparty string, counterpartyDescription string) map[string]*framework.FieldSchema {
+	return map[string]*framework.FieldSchema{
+		"name": {
+			Type: framework.TypeString,
+		},
+		"token": {
+			Type:        framework.TypeString,
+			Description: "The address of the token contract.",
+		},
+		counterparty: {
+			Type:        framework.TypeString,
+			Description: counterpartyDescription,
+		},
+		"amount": {
+			Type:        framework.TypeString,
+			Description: "Amount of tokens in raw units.",
+		},
+		"amount_decimal": {
+			Type:        framework.TypeString,
+			Description: "Amount of tokens in human units, converted with the decimals of the registered token contract.",
+		},
+		"nonce": {
+			Type:        framework.TypeString,
+			Description: "The transaction nonce.",
+		},
+		"gas_limit": {
+			Type:        framework.TypeString,
+			Description: "The gas limit for the transaction - defaults to 100000.",
+			Default:     "100000",
+		},
+		"gas_price": {
+			Type:        framework.TypeString,
+			Description: "The gas price for the transaction in wei.",
+			Default:     "0",
+		},
+		"chainID": {
+			Type:        framework.TypeString,
+			Description: "The chain ID of the blockchain network - defaults to the chain ID of the chain.",
+		},
+		"chain": {
+			Type:        framework.TypeString,
+			Description: "The name of the chain profile providing the chain ID - defaults to the chain the account was created for.",
+		},
+		"override_fee_limits": {
+			Type:        framework.TypeBool,
+			Description: "Sign even if the fee violates the fee limits of the chain, when the mount config allows fee limit overrides.",
+		},
+	}
+}
+
+func (b *PluginBackend) signERC20(counterparty string, pack func(common.Address, *big.Int) ([]byte, error)) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		dataWrapper := utils.NewFieldDataWrapper(data)
+
+		tokenStr := dataWrapper.GetString("token", "")
+		if !common.IsHexAddress(tokenStr) {
+			return nil, fmt.Errorf("invalid token address %s", tokenStr)
+		}
+		token := common.HexToAddress(tokenStr)
+
+		counterpartyStr := dataWrapper.GetString(counterparty, "")
+		if !common.IsHexAddress(counterpartyStr) {
+			return nil, fmt.Errorf("invalid %s address %s", counterparty, counterpartyStr)
+		}
+
+		nonce, err := dataWrapper.MustGetUint64("nonce")
+		if err != nil {
+			return nil, err
+		}
+
+		gasLimit, err := dataWrapper.MustGetUint64("gas_limit")
+		if err != nil {
+			return nil, err
+		}
+
+		gasPrice, err := dataWrapper.MustGetBigInt("gas_price")
+		if err != nil {
+			return nil, err
+		}
+
+		account, err := readEthereumAccount(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		profile, err := chainProfile(ctx, req, dataWrapper.GetString("chain", ""), account)
+		if err != nil {
+			return nil, err
+		}
+
+		chainID, err := evmChainID(dataWrapper, profile)
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := b.erc20Amount(ctx, req, dataWrapper, chainID, token)
+		if err != nil {
+			return nil, err
+		}
+
+		calldata, err := pack(common.HexToAddress(counterpartyStr), amount)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := b.signEthereumTransaction(ctx, req, data, account, &transactionRequest{
+			AddressTo:         &token,
+			Amount:            big.NewInt(0),
+			Nonce:             nonce,
+			GasLimit:          gasLimit,
+			GasPrice:          gasPrice,
+			ChainID:           chainID,
+			Data:              calldata,
+			Profile:           profile,
+			OverrideFeeLimits: dataWrapper.GetBool("override_fee_limits", false),
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp.Data["token_amount"] = amount.String()
+
+		return resp, nil
+	}
+}
+
+// erc20Amount resolves the token amount from either raw or human units
+func (b *PluginBackend) erc20Amount(ctx context.Context, req *logical.Request, dataWrapper *utils.FieldDataWrapper, chainID *big.Int, token common.Address) (*big.Int, error) {
+	amountDecimal := dataWrapper.GetString("amount_decimal", "")
+	amountRaw := dataWrapper.GetString("amount", "")
+
+	if amountDecimal != "" && amountRaw != "" {
+		return nil, errors.New("only one of amount and amount_decimal can be set")
+	}
+
+	if amountDecimal == "" {
+		if amountRaw == "" {
+			return nil, errors.New("amount or amount_decimal is required")
+		}
+		amount, ok := new(big.Int).SetString(amountRaw, 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, fmt.Errorf("invalid amount %s", amountRaw)
+		}
+		return amount, nil
+	}
+
+	contract, err := model.FindContract(ctx, req.Storage, chainID, token)
+	if err != nil {
+		return nil, err
+	}
+	if contract == nil || contract.Decimals == nil {
+		return nil, fmt.Errorf("token %s on chain %s has no registered decimals", token.Hex(), chainID)
+	}
+
+	return utils.ParseDecimalAmount(amountDecimal, *contract.Decimals)
+}
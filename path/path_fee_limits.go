@@ -0,0 +1,171 @@
+package path
+
+import (
+	"context"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// FeeLimitPaths returns the paths of the per-chain fee sanity bounds
+func FeeLimitPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "fee-limits/?",
+			HelpSynopsis:    "list chains with fee limits",
+			HelpDescription: `list the chain IDs which have fee limits configured`,
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{
+					Callback: b.listFeeLimits,
+					Summary:  "list chains with fee limits",
+				},
+			},
+		},
+		{
+			Pattern:         "fee-limits/(?P<chainID>[0-9]+)",
+			HelpSynopsis:    "configure the fee limits of a chain",
+			HelpDescription: `configure sanity bounds on the gas price, priority fee and total fee signed on a chain`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"chainID": {
+					Type:        framework.TypeString,
+					Description: "The chain ID of the blockchain network.",
+				},
+				"min_gas_price": {
+					Type:        framework.TypeString,
+					Description: "The minimum gas price in wei.",
+				},
+				"max_gas_price": {
+					Type:        framework.TypeString,
+					Description: "The maximum gas price in wei - defaults to 10,000 gwei.",
+				},
+				"max_priority_fee": {
+					Type:        framework.TypeString,
+					Description: "The maximum priority fee per gas in wei, checked against the whole gas price of legacy transactions.",
+				},
+				"max_fee": {
+					Type:        framework.TypeString,
+					Description: "The maximum total fee (gas limit × gas price) in wei.",
+				},
+				"max_fee_to_value_ratio": {
+					Type:        framework.TypeString,
+					Description: "The maximum ratio of the total fee to the transferred value, e.g. 0.05.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.writeFeeLimits,
+					Summary:  "configure the fee limits of a chain",
+				},
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.writeFeeLimits,
+					Summary:  "update the fee limits of a chain",
+				},
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readFeeLimits,
+					Summary:  "read the fee limits of a chain",
+				},
+				logical.DeleteOperation: &framework.PathOperation{
+					Callback: b.deleteFeeLimits,
+					Summary:  "remove the fee limits of a chain",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) listFeeLimits(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	chainIDs, err := req.Storage.List(ctx, model.FeeLimitsPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(chainIDs), nil
+}
+
+func (b *PluginBackend) writeFeeLimits(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	chainID, err := dataWrapper.MustGetBigInt("chainID")
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := model.ReadFeeLimits(ctx, req.Storage, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if limits == nil {
+		limits = &model.FeeLimits{ChainID: chainID.String()}
+	}
+
+	for field, value := range map[string]*string{
+		"min_gas_price":          &limits.MinGasPrice,
+		"max_gas_price":          &limits.MaxGasPrice,
+		"max_priority_fee":       &limits.MaxPriorityFee,
+		"max_fee":                &limits.MaxFee,
+		"max_fee_to_value_ratio": &limits.MaxFeeToValueRatio,
+	} {
+		if _, ok := data.GetOk(field); ok {
+			*value = dataWrapper.GetString(field, "")
+		}
+	}
+
+	err = limits.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := logical.StorageEntryJSON(model.FeeLimitsPrefix+limits.ChainID, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	err = req.Storage.Put(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return feeLimitsResponse(limits), nil
+}
+
+func (b *PluginBackend) readFeeLimits(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	chainID, err := utils.NewFieldDataWrapper(data).MustGetBigInt("chainID")
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := model.ReadFeeLimits(ctx, req.Storage, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if limits == nil {
+		return nil, nil
+	}
+
+	return feeLimitsResponse(limits), nil
+}
+
+func (b *PluginBackend) deleteFeeLimits(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	err := req.Storage.Delete(ctx, model.FeeLimitsPrefix+data.Get("chainID").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func feeLimitsResponse(limits *model.FeeLimits) *logical.Response {
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"chainID":                limits.ChainID,
+			"min_gas_price":          limits.MinGasPrice,
+			"max_gas_price":          limits.MaxGasPrice,
+			"max_priority_fee":       limits.MaxPriorityFee,
+			"max_fee":                limits.MaxFee,
+			"max_fee_to_value_ratio": limits.MaxFeeToValueRatio,
+		},
+	}
+}
@@ -0,0 +1,245 @@
+package path
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// HistoryPaths returns the paths of the per-account signing history
+func HistoryPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/history/?",
+			HelpSynopsis:    "list the signing history of an account",
+			HelpDescription: `list the signatures produced by an account, optionally filtered`,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"type": {
+					Type:        framework.TypeString,
+					Description: "Only return entries of this signature type.",
+				},
+				"chainID": {
+					Type:        framework.TypeString,
+					Description: "Only return entries signed for this chain ID.",
+				},
+				"address_to": {
+					Type:        framework.TypeString,
+					Description: "Only return entries sent to this address.",
+				},
+				"entity_id": {
+					Type:        framework.TypeString,
+					Description: "Only return entries requested by this entity.",
+				},
+				"since": {
+					Type:        framework.TypeString,
+					Description: "Only return entries signed at or after this RFC 3339 time.",
+				},
+				"until": {
+					Type:        framework.TypeString,
+					Description: "Only return entries signed before this RFC 3339 time.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{
+					Callback: b.listHistory,
+					Summary:  "list the signing history of an account",
+				},
+			},
+		},
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/history/verify",
+			HelpSynopsis:    "verify the signing history of an account",
+			HelpDescription: `check that the hash chain of the signing history is intact`,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.verifyHistory,
+					Summary:  "verify the signing history of an account",
+				},
+			},
+		},
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/history/(?P<sequence>[0-9]+)",
+			HelpSynopsis:    "read a signing history entry",
+			HelpDescription: `read a single entry of the signing history of an account`,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"sequence": {
+					Type:        framework.TypeString,
+					Description: "The sequence number of the entry.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readHistoryEntry,
+					Summary:  "read a signing history entry",
+				},
+			},
+		},
+	}
+}
+
+// recordSignature appends a signature to the history of the account. The
+// signature must not be released on error.
+func (b *PluginBackend) recordSignature(ctx context.Context, req *logical.Request, name string, historyEntry *model.HistoryEntry) error {
+	lock := locksutil.LockForKey(b.historyLocks, name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	historyEntry.Time = time.Now()
+	historyEntry.EntityID = req.EntityID
+
+	err := model.AppendHistory(ctx, req.Storage, name, historyEntry)
+	if err != nil {
+		return utils.ErrorHandler("history", err)
+	}
+
+	return nil
+}
+
+func (b *PluginBackend) listHistory(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+	name := data.Get("name").(string)
+
+	filter, err := newHistoryFilter(dataWrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := model.ReadHistory(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	keyInfo := map[string]interface{}{}
+	for _, historyEntry := range entries {
+		if !filter.match(historyEntry) {
+			continue
+		}
+		key := strconv.FormatUint(historyEntry.Sequence, 10)
+		keys = append(keys, key)
+		keyInfo[key] = historyEntry
+	}
+
+	return logical.ListResponseWithInfo(keys, keyInfo), nil
+}
+
+func (b *PluginBackend) readHistoryEntry(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	sequence, err := strconv.ParseUint(data.Get("sequence").(string), 10, 64)
+	if err != nil {
+		return nil, utils.ErrorHandler("sequence", err)
+	}
+
+	historyEntry, err := model.ReadHistoryEntry(ctx, req.Storage, data.Get("name").(string), sequence)
+	if err != nil {
+		return nil, err
+	}
+	if historyEntry == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"entry": historyEntry,
+		},
+	}, nil
+}
+
+func (b *PluginBackend) verifyHistory(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+
+	lock := locksutil.LockForKey(b.historyLocks, name)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	result, err := model.VerifyHistory(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"valid":     result.Valid,
+			"entries":   result.Entries,
+			"head_hash": result.HeadHash,
+		},
+	}
+	if result.Error != "" {
+		resp.Data["error"] = result.Error
+	}
+
+	return resp, nil
+}
+
+type historyFilter struct {
+	signatureType string
+	chainID       string
+	addressTo     string
+	entityID      string
+	since         time.Time
+	until         time.Time
+}
+
+func newHistoryFilter(dataWrapper *utils.FieldDataWrapper) (*historyFilter, error) {
+	filter := &historyFilter{
+		signatureType: dataWrapper.GetString("type", ""),
+		chainID:       dataWrapper.GetString("chainID", ""),
+		addressTo:     dataWrapper.GetString("address_to", ""),
+		entityID:      dataWrapper.GetString("entity_id", ""),
+	}
+
+	var err error
+	if since := dataWrapper.GetString("since", ""); since != "" {
+		filter.since, err = time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, utils.ErrorHandler("since", err)
+		}
+	}
+	if until := dataWrapper.GetString("until", ""); until != "" {
+		filter.until, err = time.Parse(time.RFC3339, until)
+		if err != nil {
+			return nil, utils.ErrorHandler("until", err)
+		}
+	}
+
+	return filter, nil
+}
+
+func (f *historyFilter) match(historyEntry *model.HistoryEntry) bool {
+	if f.signatureType != "" && historyEntry.Type != f.signatureType {
+		return false
+	}
+	if f.chainID != "" && historyEntry.ChainID != f.chainID {
+		return false
+	}
+	if f.addressTo != "" && !strings.EqualFold(historyEntry.To, f.addressTo) {
+		return false
+	}
+	if f.entityID != "" && historyEntry.EntityID != f.entityID {
+		return false
+	}
+	if !f.since.IsZero() && historyEntry.Time.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && !historyEntry.Time.Before(f.until) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,416 @@
+package path
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// PSBTPaths returns the paths of Bitcoin PSBT signing
+func PSBTPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-psbt",
+			HelpSynopsis:    "sign a Bitcoin PSBT",
+			HelpDescription: `sign every input of a BIP-174 PSBT spending from the bitcoin account, or from a receiving or change address of its BIP-44 account whose BIP-32 derivation is given by the PSBT, optionally finalizing and extracting the transaction`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"psbt": {
+					Type:        framework.TypeString,
+					Description: "The base64 encoded PSBT.",
+				},
+				"finalize": {
+					Type:        framework.TypeBool,
+					Description: "Finalize the inputs signed by the account and extract the transaction once every input is final.",
+				},
+				"allow_any_sighash": {
+					Type:        framework.TypeBool,
+					Description: "Sign inputs requesting a sighash type other than SIGHASH_ALL or SIGHASH_DEFAULT.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signPSBT,
+					Summary:  "sign a Bitcoin PSBT",
+				},
+			},
+		},
+	}
+}
+
+// readBitcoinAccount reads the account of the request and checks it holds a Bitcoin key
+func readBitcoinAccount(ctx context.Context, req *logical.Request) (*model.Account, error) {
+	return readChainAccount(ctx, req, model.ChainBitcoin)
+}
+
+// bitcoinPrivateKey reconstructs the private key of a bitcoin account
+func bitcoinPrivateKey(account *model.Account) (*btcec.PrivateKey, error) {
+	privateKeyBytes, err := hex.DecodeString(account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing private key")
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), privateKeyBytes)
+	return privateKey, nil
+}
+
+// psbtKeyring derives the keys of the receiving and change addresses of the
+// BIP-44 account of a bitcoin account from the derivations of a PSBT
+type psbtKeyring struct {
+	wallet      *model.Wallet
+	account     *model.Account
+	accountPath accounts.DerivationPath
+	fingerprint []byte
+	keys        map[string]*btcec.PrivateKey
+}
+
+func newPSBTKeyring(wallet *model.Wallet, account *model.Account) (*psbtKeyring, error) {
+	accountPath, err := model.ParseDerivationPath(account.URL)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := wallet.MasterFingerprint()
+	if err != nil {
+		return nil, err
+	}
+	return &psbtKeyring{
+		wallet:      wallet,
+		account:     account,
+		accountPath: accountPath,
+		fingerprint: fingerprint,
+		keys:        map[string]*btcec.PrivateKey{},
+	}, nil
+}
+
+// key returns the private key of the derivation, nil unless its path is a
+// receiving or change address of the account level of the account path, e.g.
+// m/84'/0'/0'/1/5 for m/84'/0'/0'/0/0, and its key the derived one
+func (k *psbtKeyring) key(derivation *model.PSBTDerivation) (*btcec.PrivateKey, error) {
+	path := derivation.Path
+	if len(path) != 5 || len(k.accountPath) != 5 || path[3] > 1 || path[4] >= hdkeychain.HardenedKeyStart {
+		return nil, nil
+	}
+	for i := 0; i < 3; i++ {
+		if path[i] != k.accountPath[i] {
+			return nil, nil
+		}
+	}
+
+	privateKey, ok := k.keys[path.String()]
+	if !ok {
+		account, err := k.wallet.DeriveBitcoin(path, k.account.AddressType, k.account.Network)
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err = bitcoinPrivateKey(account)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[path.String()] = privateKey
+	}
+
+	if !derivation.Matches(privateKey.PubKey()) {
+		return nil, nil
+	}
+	return privateKey, nil
+}
+
+// inputKeys returns the account key followed by the keys of the derivations of the input
+func (k *psbtKeyring) inputKeys(packet *model.PSBT, index int, accountKey *btcec.PrivateKey) ([]*btcec.PrivateKey, error) {
+	derivations, err := packet.InputDerivations(index, k.fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []*btcec.PrivateKey{accountKey}
+	seen := map[string]bool{string(accountKey.PubKey().SerializeCompressed()): true}
+	for _, derivation := range derivations {
+		privateKey, err := k.key(derivation)
+		if err != nil {
+			return nil, err
+		}
+		if privateKey == nil || seen[string(privateKey.PubKey().SerializeCompressed())] {
+			continue
+		}
+		seen[string(privateKey.PubKey().SerializeCompressed())] = true
+		keys = append(keys, privateKey)
+	}
+	return keys, nil
+}
+
+// isChange reports whether the output pays to an address of the account whose derivation it carries
+func (k *psbtKeyring) isChange(packet *model.PSBT, index int) (bool, error) {
+	derivations, err := packet.OutputDerivations(index, k.fingerprint)
+	if err != nil {
+		return false, err
+	}
+
+	for _, derivation := range derivations {
+		privateKey, err := k.key(derivation)
+		if err != nil {
+			return false, err
+		}
+		if privateKey == nil {
+			continue
+		}
+		script, err := model.PayToPublicKeyScript(privateKey.PubKey(), k.account.AddressType)
+		if err != nil {
+			return false, err
+		}
+		if bytes.Equal(script, packet.UnsignedTx.TxOut[index].PkScript) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// zero removes the derived keys from memory
+func (k *psbtKeyring) zero() {
+	for _, privateKey := range k.keys {
+		utils.ZeroKey(privateKey.ToECDSA())
+	}
+}
+
+func (b *PluginBackend) signPSBT(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	inputPSBT, err := dataWrapper.MustGetString("psbt")
+	if err != nil {
+		return nil, utils.ErrorHandler("psbt", err)
+	}
+
+	packet, err := model.DecodePSBT(inputPSBT)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := readBitcoinAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := model.BitcoinNetworkParams(account.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	ownScript, err := model.PayToAddressScript(account)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := bitcoinPrivateKey(account)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.ZeroKey(privateKey.ToECDSA())
+
+	wallet, err := model.ReadWallet(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := newPSBTKeyring(wallet, account)
+	if err != nil {
+		return nil, err
+	}
+	defer keyring.zero()
+
+	outputs := []map[string]interface{}{}
+	var outputTotal, sentTotal int64
+	recipient := ""
+	for i, txOut := range packet.UnsignedTx.TxOut {
+		change := bytes.Equal(txOut.PkScript, ownScript)
+		if !change {
+			change, err = keyring.isChange(packet, i)
+			if err != nil {
+				return nil, err
+			}
+		}
+		address := model.ScriptAddress(txOut.PkScript, params)
+		outputs = append(outputs, map[string]interface{}{
+			"index":   i,
+			"address": address,
+			"amount":  txOut.Value,
+			"change":  change,
+		})
+		outputTotal += txOut.Value
+		if !change {
+			sentTotal += txOut.Value
+			if recipient == "" {
+				recipient = address
+			}
+		}
+	}
+
+	// the role checks the payment of the PSBT before any input is signed
+	historyEntry := &model.HistoryEntry{
+		Type:    model.SignatureTypePSBT,
+		ChainID: account.Network,
+		To:      recipient,
+		Value:   strconv.FormatInt(sentTotal, 10),
+		Digest:  packet.UnsignedTx.TxHash().String(),
+	}
+	err = b.checkRole(ctx, req, data, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	allowAnySighash := dataWrapper.GetBool("allow_any_sighash", false)
+	finalize := dataWrapper.GetBool("finalize", false)
+	warnings := []string{}
+
+	inputs := []map[string]interface{}{}
+	signedInputs := []int{}
+	var inputTotal int64
+	feeKnown := true
+	unverifiedInputs := []int{}
+	amountsCommitted := false
+	for i, txIn := range packet.UnsignedTx.TxIn {
+		summary := map[string]interface{}{
+			"index":    i,
+			"outpoint": txIn.PreviousOutPoint.String(),
+		}
+
+		prevout, err := packet.Prevout(i)
+		if err != nil {
+			return nil, err
+		}
+		if prevout == nil {
+			feeKnown = false
+			warnings = append(warnings, fmt.Sprintf("input %d has no utxo", i))
+		} else {
+			inputTotal += prevout.Amount
+			summary["amount"] = prevout.Amount
+			summary["address"] = model.ScriptAddress(prevout.PkScript, params)
+			if !prevout.Verified {
+				unverifiedInputs = append(unverifiedInputs, i)
+			}
+		}
+
+		signed := false
+		var signingKey *btcec.PrivateKey
+		if !packet.IsFinalized(i) && prevout != nil {
+			keys, err := keyring.inputKeys(packet, i, privateKey)
+			if err != nil {
+				return nil, err
+			}
+
+			var scriptType string
+			for _, key := range keys {
+				scriptType, signed, err = packet.SignInput(i, key, allowAnySighash)
+				if err != nil {
+					return nil, err
+				}
+				if signed {
+					signingKey = key
+					break
+				}
+			}
+			summary["script_type"] = scriptType
+			if signed {
+				signedInputs = append(signedInputs, i)
+				// a taproot signature without SIGHASH_ANYONECANPAY commits to the amounts of every input
+				if hashType, _ := packet.SighashType(i, true); scriptType == model.ScriptTypeP2TR && hashType&0x80 == 0 {
+					amountsCommitted = true
+				}
+			} else if len(keys) > 1 || packet.HasDerivation(i, privateKey.PubKey()) {
+				warnings = append(warnings, fmt.Sprintf("input %d is derived from the account key but its script is not supported", i))
+			}
+		}
+		summary["signed"] = signed
+
+		if finalize && signed {
+			if _, err := packet.FinalizeInput(i, signingKey.PubKey()); err != nil {
+				return nil, err
+			}
+		}
+		summary["finalized"] = packet.IsFinalized(i)
+
+		inputs = append(inputs, summary)
+	}
+
+	if feeKnown && len(unverifiedInputs) > 0 && !amountsCommitted {
+		feeKnown = false
+		warnings = append(warnings, fmt.Sprintf("the amounts of inputs %v are not verified as they have no non-witness utxo", unverifiedInputs))
+	}
+
+	if len(signedInputs) == 0 {
+		warnings = append(warnings, fmt.Sprintf("no input of the PSBT spends from %s", account.Address))
+	}
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"address":       account.Address,
+			"txid":          packet.UnsignedTx.TxHash().String(),
+			"inputs":        inputs,
+			"outputs":       outputs,
+			"signed_inputs": signedInputs,
+			"sent":          sentTotal,
+		},
+	}
+
+	if feeKnown {
+		if inputTotal < outputTotal {
+			return nil, fmt.Errorf("the PSBT spends %d satoshis more than its inputs", outputTotal-inputTotal)
+		}
+		resp.Data["fee"] = inputTotal - outputTotal
+	} else {
+		warnings = append(warnings, "the fee is unknown as some input amounts are missing or not verified")
+	}
+
+	txHash := ""
+	if finalize {
+		complete := true
+		for i := range packet.Inputs {
+			if !packet.IsFinalized(i) {
+				complete = false
+				warnings = append(warnings, fmt.Sprintf("input %d is not finalized, the transaction is not extracted", i))
+			}
+		}
+		if complete {
+			tx, err := packet.Extract()
+			if err != nil {
+				return nil, err
+			}
+			var buf bytes.Buffer
+			if err := tx.Serialize(&buf); err != nil {
+				return nil, err
+			}
+			txHash = tx.TxHash().String()
+			resp.Data["txid"] = txHash
+			resp.Data["signed_transaction"] = hex.EncodeToString(buf.Bytes())
+		}
+	}
+
+	encoded, err := packet.Encode()
+	if err != nil {
+		return nil, err
+	}
+	resp.Data["psbt"] = encoded
+
+	if len(signedInputs) > 0 {
+		historyEntry.TxHash = txHash
+		err = b.recordSignature(ctx, req, data.Get("name").(string), historyEntry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, warning := range warnings {
+		resp.AddWarning(warning)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,272 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// RolePaths returns the paths of the signing roles
+func RolePaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "roles/?",
+			HelpSynopsis:    "list signing roles",
+			HelpDescription: `list the names of the configured signing roles`,
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{
+					Callback: b.listRoles,
+					Summary:  "list signing roles",
+				},
+			},
+		},
+		{
+			Pattern:         "roles/" + framework.GenericNameRegex("name"),
+			HelpSynopsis:    "configure a signing role",
+			HelpDescription: `configure the accounts, chains, operations and limits of the signatures requested under a role`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type:        framework.TypeString,
+					Description: "The name of the role.",
+				},
+				"accounts": {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "The account names or glob patterns the role signs with. Empty allows any.",
+				},
+				"chain_ids": {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "The chain IDs, bitcoin networks or cosmos chain IDs the role signs for. Empty allows any.",
+				},
+				"operations": {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "The signing paths the role allows, e.g. sign-tx or sign-erc20-transfer. Empty allows any.",
+				},
+				"max_value": {
+					Type:        framework.TypeString,
+					Description: "The maximum value of each signature in the smallest unit of the chain. Empty allows any.",
+				},
+				"allowed_to": {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "The destinations the role signs for. Empty allows any.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.writeRole,
+					Summary:  "configure a signing role",
+				},
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.writeRole,
+					Summary:  "update a signing role",
+				},
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readRole,
+					Summary:  "read a signing role",
+				},
+				logical.DeleteOperation: &framework.PathOperation{
+					Callback: b.deleteRole,
+					Summary:  "remove a signing role",
+				},
+			},
+		},
+	}
+}
+
+// withRolePaths adds to every signing path its copy under roles/<role>/, whose
+// signatures are checked against the role. The role being part of the path,
+// Vault ACLs decide which roles a token may sign under.
+func withRolePaths(paths []*framework.Path) []*framework.Path {
+	rolePaths := []*framework.Path{}
+	for _, p := range paths {
+		if _, ok := p.Operations[logical.CreateOperation]; !ok || !isSigningPath(p.Pattern) {
+			continue
+		}
+
+		fields := map[string]*framework.FieldSchema{
+			"role": {
+				Type:        framework.TypeString,
+				Description: "The role the signature is requested under.",
+			},
+		}
+		for field, schema := range p.Fields {
+			fields[field] = schema
+		}
+
+		operations := map[logical.Operation]framework.OperationHandler{}
+		for operation, handler := range p.Operations {
+			pathOperation := *handler.(*framework.PathOperation)
+			pathOperation.Callback = roleOperation(pathOperation.Callback)
+			pathOperation.Summary += " under a role"
+			operations[operation] = &pathOperation
+		}
+
+		rolePaths = append(rolePaths, &framework.Path{
+			Pattern:         "roles/" + framework.GenericNameRegex("role") + "/" + p.Pattern,
+			HelpSynopsis:    p.HelpSynopsis + " under a role",
+			HelpDescription: p.HelpDescription + `, checked against the restrictions of the role`,
+			ExistenceCheck:  p.ExistenceCheck,
+			Fields:          fields,
+			Operations:      operations,
+		})
+	}
+	return append(paths, rolePaths...)
+}
+
+// roleOperation runs the signing operation as if it was requested without the
+// roles/<role>/ prefix, the role staying in the role field
+func roleOperation(callback framework.OperationFunc) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		rolePath := req.Path
+		req.Path = strings.SplitN(rolePath, "/", 3)[2]
+		defer func() {
+			req.Path = rolePath
+		}()
+
+		return callback(ctx, req, data)
+	}
+}
+
+// isSigningPath reports whether the pattern is the one of a signing operation
+func isSigningPath(pattern string) bool {
+	for _, operation := range model.SigningOperations {
+		if strings.HasSuffix(pattern, "/"+operation) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *PluginBackend) listRoles(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	names, err := req.Storage.List(ctx, model.RolesPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(names), nil
+}
+
+func (b *PluginBackend) writeRole(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+	name := data.Get("name").(string)
+
+	role, err := model.ReadRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = &model.Role{Name: name}
+	}
+
+	for field, value := range map[string]*[]string{
+		"accounts":   &role.Accounts,
+		"chain_ids":  &role.ChainIDs,
+		"operations": &role.Operations,
+		"allowed_to": &role.AllowedTo,
+	} {
+		if list, ok := data.GetOk(field); ok {
+			*value = list.([]string)
+		}
+	}
+	if _, ok := data.GetOk("max_value"); ok {
+		role.MaxValue = dataWrapper.GetString("max_value", "")
+	}
+
+	err = role.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := logical.StorageEntryJSON(model.RolesPrefix+name, role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = req.Storage.Put(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return roleResponse(role), nil
+}
+
+func (b *PluginBackend) readRole(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	role, err := model.ReadRole(ctx, req.Storage, data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	return roleResponse(role), nil
+}
+
+func (b *PluginBackend) deleteRole(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	err := req.Storage.Delete(ctx, model.RolesPrefix+data.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func roleResponse(role *model.Role) *logical.Response {
+	lists := map[string][]string{
+		"accounts":   role.Accounts,
+		"chain_ids":  role.ChainIDs,
+		"operations": role.Operations,
+		"allowed_to": role.AllowedTo,
+	}
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"name":      role.Name,
+			"max_value": role.MaxValue,
+		},
+	}
+	for field, list := range lists {
+		if list == nil {
+			list = []string{}
+		}
+		resp.Data[field] = list
+	}
+
+	return resp
+}
+
+// checkRole checks the signature of the account against the role of the
+// request path, before the signature is made. Requests outside roles/ are
+// allowed unless the mount config requires a role.
+func (b *PluginBackend) checkRole(ctx context.Context, req *logical.Request, data *framework.FieldData, name string, historyEntry *model.HistoryEntry) error {
+	roleName := ""
+	if value, ok := data.GetOk("role"); ok {
+		roleName = value.(string)
+	}
+	if roleName == "" {
+		config, err := model.ReadConfig(ctx, req.Storage)
+		if err != nil {
+			return err
+		}
+		if config.RequireRole {
+			return fmt.Errorf("the mount config requires signatures to be requested under roles/<role>/")
+		}
+		return nil
+	}
+
+	role, err := model.ReadRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return fmt.Errorf("role %s is not existed", roleName)
+	}
+
+	return role.Check(name, path.Base(req.Path), historyEntry)
+}
@@ -0,0 +1,269 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// Signature formats of Safe owner signatures
+const (
+	safeSignatureEIP712  = "eip712"
+	safeSignatureEthSign = "eth_sign"
+)
+
+// SafePaths returns the paths of Gnosis Safe owner signing
+func SafePaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-safe-tx",
+			HelpSynopsis:    "sign a Safe transaction as an owner",
+			HelpDescription: `compute the safeTxHash of a SafeTx and return an owner signature in the format the Safe contracts expect`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"safe_address": {
+					Type:        framework.TypeString,
+					Description: "The address of the Safe.",
+				},
+				"safe_version": {
+					Type:        framework.TypeString,
+					Description: "The version of the Safe contracts, 1.0.0 or later - defaults to 1.3.0.",
+					Default:     "1.3.0",
+				},
+				"chainID": {
+					Type:        framework.TypeString,
+					Description: "The chain ID of the blockchain network.",
+				},
+				"to": {
+					Type:        framework.TypeString,
+					Description: "The destination of the Safe transaction.",
+				},
+				"value": {
+					Type:        framework.TypeString,
+					Description: "Amount of ETH (in wei).",
+					Default:     "0",
+				},
+				"data": {
+					Type:        framework.TypeString,
+					Description: "The calldata of the Safe transaction.",
+				},
+				"operation": {
+					Type:        framework.TypeInt,
+					Description: "0 for call, 1 for delegatecall.",
+					Default:     0,
+				},
+				"safe_tx_gas": {
+					Type:        framework.TypeString,
+					Description: "The gas for the Safe transaction.",
+					Default:     "0",
+				},
+				"base_gas": {
+					Type:        framework.TypeString,
+					Description: "The gas costs independent of the transaction execution.",
+					Default:     "0",
+				},
+				"gas_price": {
+					Type:        framework.TypeString,
+					Description: "The gas price used for the refund calculation.",
+					Default:     "0",
+				},
+				"gas_token": {
+					Type:        framework.TypeString,
+					Description: "The token used for the refund, the zero address for ETH.",
+					Default:     common.Address{}.Hex(),
+				},
+				"refund_receiver": {
+					Type:        framework.TypeString,
+					Description: "The address receiving the refund, the zero address for tx.origin.",
+					Default:     common.Address{}.Hex(),
+				},
+				"nonce": {
+					Type:        framework.TypeString,
+					Description: "The nonce of the Safe.",
+				},
+				"signature_type": {
+					Type:        framework.TypeString,
+					Description: "eip712 to sign the safeTxHash directly, eth_sign to sign it with the personal message prefix - defaults to eip712.",
+					Default:     safeSignatureEIP712,
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signSafeTransaction,
+					Summary:  "sign a Safe transaction as an owner",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) signSafeTransaction(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	addresses := map[string]common.Address{}
+	for _, key := range []string{"safe_address", "to", "gas_token", "refund_receiver"} {
+		value := dataWrapper.GetString(key, "")
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid %s %s", key, value)
+		}
+		addresses[key] = common.HexToAddress(value)
+	}
+
+	numbers := map[string]*big.Int{}
+	for _, key := range []string{"value", "safe_tx_gas", "base_gas", "gas_price"} {
+		value, err := dataWrapper.MustGetBigInt(key)
+		if err != nil || value == nil {
+			return nil, fmt.Errorf("invalid %s", key)
+		}
+		numbers[key] = value
+	}
+	for _, key := range []string{"chainID", "nonce"} {
+		value, err := requiredNumber(dataWrapper, key)
+		if err != nil {
+			return nil, err
+		}
+		numbers[key] = value
+	}
+	if numbers["chainID"].Sign() <= 0 {
+		return nil, fmt.Errorf("invalid chainID %s", numbers["chainID"])
+	}
+
+	var calldata []byte
+	if inputData := dataWrapper.GetString("data", ""); inputData != "" {
+		var err error
+		calldata, err = hexutil.Decode(inputData)
+		if err != nil {
+			return nil, utils.ErrorHandler("data", err)
+		}
+	}
+
+	operation := data.Get("operation").(int)
+	if operation != 0 && operation != 1 {
+		return nil, fmt.Errorf("invalid operation %d, expected 0 (call) or 1 (delegatecall)", operation)
+	}
+
+	signatureType := dataWrapper.GetString("signature_type", safeSignatureEIP712)
+	if signatureType != safeSignatureEIP712 && signatureType != safeSignatureEthSign {
+		return nil, fmt.Errorf("unsupported signature_type %s", signatureType)
+	}
+
+	safeTx := &model.SafeTransaction{
+		Safe:           addresses["safe_address"],
+		ChainID:        numbers["chainID"],
+		Version:        dataWrapper.GetString("safe_version", "1.3.0"),
+		To:             addresses["to"],
+		Value:          numbers["value"],
+		Data:           calldata,
+		Operation:      uint8(operation),
+		SafeTxGas:      numbers["safe_tx_gas"],
+		BaseGas:        numbers["base_gas"],
+		GasPrice:       numbers["gas_price"],
+		GasToken:       addresses["gas_token"],
+		RefundReceiver: addresses["refund_receiver"],
+		Nonce:          numbers["nonce"],
+	}
+
+	safeTxHash, err := safeTx.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := readEthereumAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedCall, calldataWarning, err := b.decodeCalldata(ctx, req, account, safeTx.ChainID, safeTx.To, safeTx.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the Safe executes the call, recorded by the beneficiary and amount it transfers
+	beneficiary, amount, _ := model.CallTransfer(safeTx.To, safeTx.Value, safeTx.Data)
+	historyEntry := &model.HistoryEntry{
+		Type:     model.SignatureTypeSafeTransaction,
+		ChainID:  safeTx.ChainID.String(),
+		To:       beneficiary.Hex(),
+		Value:    amount.String(),
+		Contract: safeTx.Safe.Hex(),
+		Digest:   safeTxHash.Hex(),
+	}
+	err = b.checkRole(ctx, req, data, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.HexToECDSA(account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing private key")
+	}
+	defer utils.ZeroKey(privateKey)
+
+	var signature []byte
+	if signatureType == safeSignatureEthSign {
+		// the Safe contracts tell eth_sign signatures apart by v + 4
+		signature, _, err = utils.SignPersonalMessage(safeTxHash.Bytes(), privateKey)
+		if err != nil {
+			return nil, err
+		}
+		signature[crypto.RecoveryIDOffset] += 4
+	} else {
+		signature, err = crypto.Sign(safeTxHash.Bytes(), privateKey)
+		if err != nil {
+			return nil, err
+		}
+		signature[crypto.RecoveryIDOffset] += 27
+	}
+
+	err = b.recordSignature(ctx, req, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"owner":        account.Address,
+			"safe_tx_hash": safeTxHash.Hex(),
+			"signature":    hexutil.Encode(signature),
+		},
+	}
+	if decodedCall != nil {
+		resp.Data["decoded_call"] = decodedCall
+	}
+	if calldataWarning != "" {
+		resp.AddWarning(calldataWarning)
+	}
+	if safeTx.Operation == 1 {
+		resp.AddWarning("the Safe transaction is a delegatecall")
+	}
+
+	return resp, nil
+}
+
+// requiredNumber returns the non-negative integer of a field which must be
+// given, such as a chain ID or nonce, which must not default to 0 in a signed hash
+func requiredNumber(dataWrapper *utils.FieldDataWrapper, key string) (*big.Int, error) {
+	value := dataWrapper.GetString(key, "")
+	if value == "" {
+		return nil, fmt.Errorf("%s is required", key)
+	}
+
+	number, ok := math.ParseBig256(value)
+	if !ok || number.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s %s", key, value)
+	}
+
+	return number, nil
+}
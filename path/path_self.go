@@ -0,0 +1,191 @@
+package path
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// SelfPaths returns the paths of the account of the requesting entity
+func SelfPaths(b *PluginBackend) []*framework.Path {
+	signTxFields := signTransactionFields()
+	delete(signTxFields, "name")
+
+	return []*framework.Path{
+		{
+			Pattern:         "self/address",
+			HelpSynopsis:    "get the address of the own account",
+			HelpDescription: `get the address of the account bound to the requesting entity, deriving it on first use`,
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.selfOperation(b.readAddress),
+					Summary:  "get the address of the own account",
+				},
+			},
+		},
+		{
+			Pattern:         "self/sign",
+			HelpSynopsis:    "sign data with the own account",
+			HelpDescription: `sign data with the account bound to the requesting entity, deriving it on first use`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"data": {
+					Type:        framework.TypeString,
+					Description: "The data to sign.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.selfOperation(b.signData),
+					Summary:  "sign data with the own account",
+				},
+			},
+		},
+		{
+			Pattern:         "self/sign-tx",
+			HelpSynopsis:    "sign a transaction with the own account",
+			HelpDescription: `sign a transaction with the account bound to the requesting entity, deriving it on first use`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields:          signTxFields,
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.selfOperation(b.signTransaction),
+					Summary:  "sign a transaction with the own account",
+				},
+			},
+		},
+	}
+}
+
+// selfOperation runs the account operation on the account bound to the
+// requesting entity, as if it was requested on accounts/<name>/
+func (b *PluginBackend) selfOperation(callback framework.OperationFunc) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		if req.EntityID == "" {
+			return nil, errors.New("self paths require a request from an identity entity")
+		}
+
+		name, err := b.provisionSelfAccount(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		schema := map[string]*framework.FieldSchema{
+			"name": {
+				Type: framework.TypeString,
+			},
+		}
+		raw := map[string]interface{}{}
+		for field, fieldSchema := range data.Schema {
+			schema[field] = fieldSchema
+		}
+		for field, value := range data.Raw {
+			raw[field] = value
+		}
+		raw["name"] = name
+
+		selfPath := req.Path
+		req.Path = "accounts/" + name + "/" + strings.TrimPrefix(selfPath, "self/")
+		defer func() {
+			req.Path = selfPath
+		}()
+
+		return callback(ctx, req, &framework.FieldData{Raw: raw, Schema: schema})
+	}
+}
+
+// provisionSelfAccount returns the name of the account bound to the requesting
+// entity, deriving an Ethereum account at the next index of the branch reserved
+// for self-provisioned accounts if the entity has none
+func (b *PluginBackend) provisionSelfAccount(ctx context.Context, req *logical.Request) (string, error) {
+	name := model.SelfAccountName(req.EntityID)
+	accountPath := "accounts/" + name
+
+	b.selfLock.Lock()
+	defer b.selfLock.Unlock()
+
+	entry, err := req.Storage.Get(ctx, accountPath)
+	if err != nil {
+		return "", err
+	}
+	if entry != nil {
+		// the account must have been provisioned for the entity, not stored under its name otherwise
+		var account *model.Account
+		err = entry.DecodeJSON(&account)
+		if err != nil {
+			return "", errors.New("Fail to decode account to JSON format")
+		}
+		if account.EntityID != req.EntityID {
+			return "", fmt.Errorf("account %s is not bound to the requesting entity", name)
+		}
+		return name, nil
+	}
+
+	config, err := model.ReadConfig(ctx, req.Storage)
+	if err != nil {
+		return "", err
+	}
+	if !config.AllowsChain(model.ChainEthereum) {
+		return "", fmt.Errorf("%s accounts are not allowed by the mount config", model.ChainEthereum)
+	}
+
+	wallet, err := model.ReadWallet(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	index := uint64(0)
+	indexEntry, err := req.Storage.Get(ctx, model.SelfIndexPath)
+	if err != nil {
+		return "", err
+	}
+	if indexEntry != nil {
+		index, err = strconv.ParseUint(string(indexEntry.Value), 10, 32)
+		if err != nil {
+			return "", errors.New("Fail to decode the index of self-provisioned accounts")
+		}
+	}
+
+	derivationPath, err := model.SelfDerivationPath(uint32(index))
+	if err != nil {
+		return "", err
+	}
+
+	account, err := wallet.Derive(derivationPath)
+	if err != nil {
+		return "", err
+	}
+	account.EntityID = req.EntityID
+
+	entry, err = logical.StorageEntryJSON(accountPath, account)
+	if err != nil {
+		return "", err
+	}
+
+	err = req.Storage.Put(ctx, entry)
+	if err != nil {
+		return "", err
+	}
+
+	err = model.StartHistory(ctx, req.Storage, name)
+	if err != nil {
+		return "", utils.ErrorHandler("history", err)
+	}
+
+	err = req.Storage.Put(ctx, &logical.StorageEntry{
+		Key:   model.SelfIndexPath,
+		Value: []byte(strconv.FormatUint(index+1, 10)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
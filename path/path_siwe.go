@@ -0,0 +1,177 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// SIWEPaths returns the paths of Sign-In with Ethereum (EIP-4361) signing
+func SIWEPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-siwe",
+			HelpSynopsis:    "sign a Sign-In with Ethereum message",
+			HelpDescription: `render an EIP-4361 message from its fields and sign it with personal_sign semantics`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"scheme": {
+					Type:        framework.TypeString,
+					Description: "The URI scheme of the origin of the request.",
+				},
+				"domain": {
+					Type:        framework.TypeString,
+					Description: "The RFC 3986 authority that is requesting the signing.",
+				},
+				"statement": {
+					Type:        framework.TypeString,
+					Description: "A human-readable assertion the user signs.",
+				},
+				"uri": {
+					Type:        framework.TypeString,
+					Description: "The RFC 3986 URI referring to the resource that is the subject of the signing.",
+				},
+				"version": {
+					Type:        framework.TypeString,
+					Description: "The version of the message - defaults to 1.",
+					Default:     "1",
+				},
+				"chainID": {
+					Type:        framework.TypeString,
+					Description: "The chain ID to which the session is bound.",
+				},
+				"nonce": {
+					Type:        framework.TypeString,
+					Description: "A randomized token of at least 8 alphanumeric characters.",
+				},
+				"issued_at": {
+					Type:        framework.TypeString,
+					Description: "The RFC 3339 time when the message was generated - defaults to now.",
+				},
+				"expiration_time": {
+					Type:        framework.TypeString,
+					Description: "The RFC 3339 time when the signed message expires.",
+				},
+				"not_before": {
+					Type:        framework.TypeString,
+					Description: "The RFC 3339 time when the signed message becomes valid.",
+				},
+				"request_id": {
+					Type:        framework.TypeString,
+					Description: "A system-specific identifier of the sign-in request.",
+				},
+				"resources": {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "The URIs the user wishes to have resolved as part of authentication.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signSIWE,
+					Summary:  "sign a Sign-In with Ethereum message",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) signSIWE(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	account, err := readEthereumAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := dataWrapper.MustGetBigInt("chainID")
+	if err != nil {
+		return nil, utils.ErrorHandler("chainID", err)
+	}
+
+	message := &model.SIWEMessage{
+		Scheme:    dataWrapper.GetString("scheme", ""),
+		Domain:    dataWrapper.GetString("domain", ""),
+		Address:   account.Address,
+		Statement: dataWrapper.GetString("statement", ""),
+		URI:       dataWrapper.GetString("uri", ""),
+		Version:   dataWrapper.GetString("version", "1"),
+		ChainID:   chainID,
+		Nonce:     dataWrapper.GetString("nonce", ""),
+		IssuedAt:  time.Now().UTC().Truncate(time.Second),
+		RequestID: dataWrapper.GetString("request_id", ""),
+		Resources: data.Get("resources").([]string),
+	}
+
+	if issuedAt := dataWrapper.GetString("issued_at", ""); issuedAt != "" {
+		message.IssuedAt, err = time.Parse(time.RFC3339, issuedAt)
+		if err != nil {
+			return nil, utils.ErrorHandler("issued_at", err)
+		}
+	}
+	if expirationTime := dataWrapper.GetString("expiration_time", ""); expirationTime != "" {
+		parsed, err := time.Parse(time.RFC3339, expirationTime)
+		if err != nil {
+			return nil, utils.ErrorHandler("expiration_time", err)
+		}
+		message.ExpirationTime = &parsed
+	}
+	if notBefore := dataWrapper.GetString("not_before", ""); notBefore != "" {
+		parsed, err := time.Parse(time.RFC3339, notBefore)
+		if err != nil {
+			return nil, utils.ErrorHandler("not_before", err)
+		}
+		message.NotBefore = &parsed
+	}
+
+	err = message.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := message.String()
+	historyEntry := &model.HistoryEntry{
+		Type:    model.SignatureTypeSIWE,
+		ChainID: chainID.String(),
+		To:      message.Domain,
+		Digest:  hexutil.Encode(accounts.TextHash([]byte(rendered))),
+	}
+	err = b.checkRole(ctx, req, data, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.HexToECDSA(account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing private key")
+	}
+	defer utils.ZeroKey(privateKey)
+
+	signature, _, err := utils.SignPersonalMessage([]byte(rendered), privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.recordSignature(ctx, req, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"address":   account.Address,
+			"message":   rendered,
+			"signature": hexutil.Encode(signature),
+		},
+	}, nil
+}
@@ -0,0 +1,321 @@
+package path
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// SLIP39Paths returns the paths of the SLIP-39 backups of the wallet
+func SLIP39Paths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "wallet/slip39/export",
+			HelpSynopsis:    "split the wallet seed into SLIP-39 shares",
+			HelpDescription: `split the wallet seed into SLIP-39 shares, which are then delivered one by one through wallet/slip39/shares`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields:          slip39Fields(),
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.exportSLIP39,
+					Summary:  "split the wallet seed into SLIP-39 shares",
+				},
+			},
+		},
+		{
+			Pattern:         "wallet/slip39/import",
+			HelpSynopsis:    "restore the wallet from SLIP-39 shares",
+			HelpDescription: `restore the wallet from the seed recovered from enough SLIP-39 shares`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"shares": {
+					Type:         framework.TypeCommaStringSlice,
+					Description:  "The SLIP-39 mnemonic shares recovering the seed.",
+					DisplayAttrs: sensitive,
+				},
+				"share_passphrase": {
+					Type:         framework.TypeString,
+					Default:      "",
+					Description:  "The passphrase the shares were encrypted with.",
+					DisplayAttrs: sensitive,
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.importSLIP39,
+					Summary:  "restore the wallet from SLIP-39 shares",
+				},
+			},
+		},
+		{
+			Pattern:         "wallet/slip39/shares/?",
+			HelpSynopsis:    "list the undelivered SLIP-39 shares",
+			HelpDescription: `list the SLIP-39 shares which have not been read yet`,
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{
+					Callback: b.listSLIP39Shares,
+					Summary:  "list the undelivered SLIP-39 shares",
+				},
+			},
+		},
+		{
+			Pattern:         "wallet/slip39/shares/" + framework.GenericNameRegex("share"),
+			HelpSynopsis:    "deliver a SLIP-39 share",
+			HelpDescription: `deliver a SLIP-39 share once through response wrapping, removing it from the plugin`,
+			Fields: map[string]*framework.FieldSchema{
+				"share": {
+					Type:        framework.TypeString,
+					Description: "The share, as <group>-<member> numbered from 1.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readSLIP39Share,
+					Summary:  "deliver a SLIP-39 share",
+				},
+				logical.DeleteOperation: &framework.PathOperation{
+					Callback: b.deleteSLIP39Share,
+					Summary:  "discard an undelivered SLIP-39 share",
+				},
+			},
+		},
+	}
+}
+
+// slip39Fields returns the fields splitting the wallet seed into SLIP-39 shares
+func slip39Fields() map[string]*framework.FieldSchema {
+	return map[string]*framework.FieldSchema{
+		"groups": {
+			Type:        framework.TypeCommaStringSlice,
+			Description: "The member threshold and count of each share group, e.g. 2/3,3/5.",
+		},
+		"group_threshold": {
+			Type:        framework.TypeInt,
+			Default:     1,
+			Description: "The number of groups needed to recover the seed.",
+		},
+		"share_passphrase": {
+			Type:         framework.TypeString,
+			Default:      "",
+			Description:  "The passphrase encrypting the shares.",
+			DisplayAttrs: sensitive,
+		},
+		"iteration_exponent": {
+			Type:        framework.TypeInt,
+			Default:     model.DefaultSLIP39IterationExponent,
+			Description: "The exponent of the key derivation iterations encrypting the shares.",
+		},
+	}
+}
+
+// splitWallet splits the seed of the wallet into the SLIP-39 shares requested by the fields
+func splitWallet(wallet *model.Wallet, data *framework.FieldData) ([][]string, error) {
+	if !wallet.HasSeed() {
+		return nil, model.ErrWalletWithoutSeed
+	}
+
+	groups, err := model.ParseSLIP39Groups(data.Get("groups").([]string))
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := hex.DecodeString(wallet.Seed)
+	if err != nil {
+		return nil, errors.New("Fail to decode the wallet seed")
+	}
+
+	return model.SplitSLIP39(seed, data.Get("share_passphrase").(string), data.Get("group_threshold").(int), groups, data.Get("iteration_exponent").(int))
+}
+
+// checkExportable refuses exporting the secrets of non-exportable wallets,
+// or of any wallet when the mount config disables returning them
+func checkExportable(config *model.Config, wallet *model.Wallet) error {
+	if !wallet.Exportable() {
+		return errors.New("the wallet is not exportable")
+	}
+	if !config.ReturnMnemonic {
+		return errors.New("exporting the wallet is disabled by the mount config")
+	}
+	return nil
+}
+
+func (b *PluginBackend) exportSLIP39(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := model.ReadConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := model.ReadWallet(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkExportable(config, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := splitWallet(wallet, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.storeSLIP39Shares(ctx, req, model.SLIP39SharesPrefix, shares)
+}
+
+func (b *PluginBackend) importSLIP39(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	exists, err := model.WalletExists(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("a wallet already exists, SLIP-39 shares only restore a mount without wallet")
+	}
+
+	seed, err := model.CombineSLIP39(data.Get("shares").([]string), data.Get("share_passphrase").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := model.NewWalletFromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+	wallet.Source = model.WalletSourceSLIP39
+
+	err = putWallet(ctx, req, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	err = clearSLIP39Shares(ctx, req, model.SLIP39SharesPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletMetadataResponse(wallet), nil
+}
+
+// storeSLIP39Shares replaces the undelivered shares under the storage prefix with
+// the shares, which are read one by one so that no single response contains the whole seed
+func (b *PluginBackend) storeSLIP39Shares(ctx context.Context, req *logical.Request, prefix string, shares [][]string) (*logical.Response, error) {
+	err := clearSLIP39Shares(ctx, req, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	groups := []string{}
+	groupThreshold := 0
+	for groupIndex, mnemonics := range shares {
+		for memberIndex, mnemonic := range mnemonics {
+			share, err := model.DecodeSLIP39Share(mnemonic)
+			if err != nil {
+				return nil, err
+			}
+			if memberIndex == 0 {
+				groupThreshold = share.GroupThreshold
+				groups = append(groups, fmt.Sprintf("%d/%d", share.MemberThreshold, len(mnemonics)))
+			}
+
+			name := fmt.Sprintf("%d-%d", groupIndex+1, memberIndex+1)
+			err = req.Storage.Put(ctx, &logical.StorageEntry{
+				Key:   prefix + name,
+				Value: []byte(mnemonic),
+			})
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, name)
+		}
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"group_threshold": groupThreshold,
+			"groups":          groups,
+			"shares":          names,
+		},
+	}, nil
+}
+
+func clearSLIP39Shares(ctx context.Context, req *logical.Request, prefix string) error {
+	names, err := req.Storage.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		err = req.Storage.Delete(ctx, prefix+name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *PluginBackend) listSLIP39Shares(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	names, err := req.Storage.List(ctx, model.SLIP39SharesPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(names), nil
+}
+
+func (b *PluginBackend) readSLIP39Share(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	return deliverSLIP39Share(ctx, req, model.SLIP39SharesPrefix, data.Get("share").(string))
+}
+
+// deliverSLIP39Share returns the undelivered share under the storage prefix once, through response wrapping
+func deliverSLIP39Share(ctx context.Context, req *logical.Request, prefix string, name string) (*logical.Response, error) {
+	err := requireResponseWrapping(req)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := req.Storage.Get(ctx, prefix+name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("share %s is not existed or was already delivered", name)
+	}
+
+	share, err := model.DecodeSLIP39Share(string(entry.Value))
+	if err != nil {
+		return nil, err
+	}
+
+	err = req.Storage.Delete(ctx, entry.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"share":            name,
+			"mnemonic":         string(entry.Value),
+			"group":            share.GroupIndex + 1,
+			"group_threshold":  share.GroupThreshold,
+			"member":           share.MemberIndex + 1,
+			"member_threshold": share.MemberThreshold,
+		},
+	}, nil
+}
+
+func (b *PluginBackend) deleteSLIP39Share(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	err := req.Storage.Delete(ctx, model.SLIP39SharesPrefix+data.Get("share").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
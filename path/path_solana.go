@@ -0,0 +1,204 @@
+package path
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// Encodings of serialized Solana messages and transactions
+const (
+	solanaEncodingBase64 = "base64"
+	solanaEncodingBase58 = "base58"
+)
+
+// SolanaPaths returns the paths of Solana transaction signing
+func SolanaPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-solana-tx",
+			HelpSynopsis:    "sign a Solana transaction",
+			HelpDescription: `sign a serialized legacy or v0 Solana message and return the signed transaction`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"message": {
+					Type:        framework.TypeString,
+					Description: "The serialized message to sign.",
+				},
+				"transaction": {
+					Type:        framework.TypeString,
+					Description: "A serialized transaction, possibly signed by other signers, to sign instead of a message.",
+				},
+				"encoding": {
+					Type:        framework.TypeString,
+					Description: "The encoding of the message and transaction, base64 or base58 - defaults to base64.",
+					Default:     solanaEncodingBase64,
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signSolanaTransaction,
+					Summary:  "sign a Solana transaction",
+				},
+			},
+		},
+	}
+}
+
+func decodeSolana(value string, encoding string) ([]byte, error) {
+	switch encoding {
+	case solanaEncodingBase64:
+		return base64.StdEncoding.DecodeString(value)
+	case solanaEncodingBase58:
+		decoded := base58.Decode(value)
+		if len(decoded) == 0 {
+			return nil, errors.New("invalid base58 string")
+		}
+		return decoded, nil
+	}
+	return nil, fmt.Errorf("unsupported encoding %s", encoding)
+}
+
+func encodeSolana(value []byte, encoding string) string {
+	if encoding == solanaEncodingBase58 {
+		return base58.Encode(value)
+	}
+	return base64.StdEncoding.EncodeToString(value)
+}
+
+func (b *PluginBackend) signSolanaTransaction(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	encoding := dataWrapper.GetString("encoding", solanaEncodingBase64)
+	inputMessage := dataWrapper.GetString("message", "")
+	inputTransaction := dataWrapper.GetString("transaction", "")
+	if (inputMessage == "") == (inputTransaction == "") {
+		return nil, errors.New("either message or transaction is required")
+	}
+
+	var rawMessage []byte
+	var signatures [][]byte
+	if inputMessage != "" {
+		decoded, err := decodeSolana(inputMessage, encoding)
+		if err != nil {
+			return nil, utils.ErrorHandler("message", err)
+		}
+		rawMessage = decoded
+	} else {
+		decoded, err := decodeSolana(inputTransaction, encoding)
+		if err != nil {
+			return nil, utils.ErrorHandler("transaction", err)
+		}
+		signatures, rawMessage, err = model.ParseSolanaTransaction(decoded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	message, err := model.ParseSolanaMessage(rawMessage)
+	if err != nil {
+		return nil, err
+	}
+	if signatures == nil {
+		signatures = make([][]byte, message.NumRequiredSignature)
+	}
+	if len(signatures) != message.NumRequiredSignature {
+		return nil, fmt.Errorf("the transaction has %d signatures but its message requires %d", len(signatures), message.NumRequiredSignature)
+	}
+
+	account, err := readChainAccount(ctx, req, model.ChainSolana)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := hex.DecodeString(account.PrivateKey)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("error reconstructing private key")
+	}
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	defer func() {
+		for i := range privateKey {
+			privateKey[i] = 0
+		}
+	}()
+
+	signerIndex := message.SignerIndex(privateKey.Public().(ed25519.PublicKey))
+	if signerIndex < 0 {
+		return nil, fmt.Errorf("account %s is not a signer of the message", account.Address)
+	}
+
+	messageHash := sha256.Sum256(rawMessage)
+	historyEntry := &model.HistoryEntry{
+		Type:   model.SignatureTypeSolana,
+		Digest: hex.EncodeToString(messageHash[:]),
+	}
+	err = b.checkRole(ctx, req, data, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := ed25519.Sign(privateKey, rawMessage)
+	signatures[signerIndex] = signature
+
+	missing := 0
+	for i, existing := range signatures {
+		if len(existing) != ed25519.SignatureSize {
+			signatures[i] = make([]byte, ed25519.SignatureSize)
+		}
+		if isZeroSignature(signatures[i]) {
+			missing++
+		}
+	}
+
+	if signerIndex == 0 {
+		// the first signature, made by the fee payer, identifies the transaction
+		historyEntry.TxHash = base58.Encode(signature)
+	}
+	err = b.recordSignature(ctx, req, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	version := "legacy"
+	if message.Version >= 0 {
+		version = fmt.Sprintf("%d", message.Version)
+	}
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"address":            account.Address,
+			"signature":          base58.Encode(signature),
+			"signer_index":       signerIndex,
+			"fee_payer":          base58.Encode(message.AccountKeys[0]),
+			"recent_blockhash":   base58.Encode(message.RecentBlockhash),
+			"version":            version,
+			"signed_transaction": encodeSolana(model.SolanaTransaction(signatures, rawMessage), encoding),
+		},
+	}
+	if missing > 0 {
+		resp.AddWarning(fmt.Sprintf("the transaction still misses %d signatures", missing))
+	}
+
+	return resp, nil
+}
+
+func isZeroSignature(signature []byte) bool {
+	for _, b := range signature {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
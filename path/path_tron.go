@@ -0,0 +1,114 @@
+package path
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// TronPaths returns the paths of TRON transaction signing
+func TronPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-tron-tx",
+			HelpSynopsis:    "sign a TRON transaction",
+			HelpDescription: `check the txID of a TRON transaction against its raw_data and sign it`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"raw_data_hex": {
+					Type:        framework.TypeString,
+					Description: "The protobuf raw_data of the transaction in hex.",
+				},
+				"txid": {
+					Type:        framework.TypeString,
+					Description: "The txID of the transaction, the SHA-256 of its raw_data.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signTronTransaction,
+					Summary:  "sign a TRON transaction",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) signTronTransaction(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	rawDataHex, err := dataWrapper.MustGetString("raw_data_hex")
+	if err != nil {
+		return nil, utils.ErrorHandler("raw_data_hex", err)
+	}
+	rawData, err := hex.DecodeString(rawDataHex)
+	if err != nil || len(rawData) == 0 {
+		return nil, fmt.Errorf("invalid raw_data_hex")
+	}
+
+	inputTxID, err := dataWrapper.MustGetString("txid")
+	if err != nil {
+		return nil, utils.ErrorHandler("txid", err)
+	}
+	txID := model.TronTransactionID(rawData)
+	if expected, err := hex.DecodeString(inputTxID); err != nil || !bytes.Equal(expected, txID) {
+		return nil, fmt.Errorf("txid %s does not match the raw_data, expected %s", inputTxID, hex.EncodeToString(txID))
+	}
+
+	account, err := readChainAccount(ctx, req, model.ChainTron)
+	if err != nil {
+		return nil, err
+	}
+
+	historyEntry := &model.HistoryEntry{
+		Type:   model.SignatureTypeTron,
+		Digest: hex.EncodeToString(txID),
+		TxHash: hex.EncodeToString(txID),
+	}
+	err = b.checkRole(ctx, req, data, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.HexToECDSA(account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing private key")
+	}
+	defer utils.ZeroKey(privateKey)
+
+	signature, err := crypto.Sign(txID, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	// TRON signatures carry the recovery ID as 27 or 28, like TronWeb produces them
+	signature[crypto.RecoveryIDOffset] += 27
+
+	signedTransaction, err := model.TronSignedTransaction(rawData, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.recordSignature(ctx, req, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"address":            account.Address,
+			"txid":               hex.EncodeToString(txID),
+			"signature":          hex.EncodeToString(signature),
+			"signed_transaction": hex.EncodeToString(signedTransaction),
+		},
+	}, nil
+}
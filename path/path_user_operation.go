@@ -0,0 +1,257 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// Signature formats of user operation signatures
+const (
+	userOpSignatureEthSign = "eth_sign"
+	userOpSignatureRaw     = "raw"
+)
+
+// UserOperationPaths returns the paths of ERC-4337 user operation signing
+func UserOperationPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-user-operation",
+			HelpSynopsis:    "sign an ERC-4337 user operation",
+			HelpDescription: `compute the userOpHash as the EntryPoint does and sign it as the owner of a smart account`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"entry_point": {
+					Type:        framework.TypeString,
+					Description: "The address of the EntryPoint.",
+				},
+				"entry_point_version": {
+					Type:        framework.TypeString,
+					Description: "The UserOperation layout of the EntryPoint, 0.6 or 0.7 (packed) - defaults to 0.7.",
+					Default:     model.EntryPointV07,
+				},
+				"chainID": {
+					Type:        framework.TypeString,
+					Description: "The chain ID of the blockchain network.",
+				},
+				"sender": {
+					Type:        framework.TypeString,
+					Description: "The smart account sending the operation.",
+				},
+				"nonce": {
+					Type:        framework.TypeString,
+					Description: "The nonce of the smart account.",
+				},
+				"init_code": {
+					Type:        framework.TypeString,
+					Description: "The factory address followed by its calldata, empty if the account is deployed.",
+				},
+				"call_data": {
+					Type:        framework.TypeString,
+					Description: "The calldata of the smart account execution.",
+				},
+				"call_gas_limit": {
+					Type:        framework.TypeString,
+					Description: "The gas limit of the execution.",
+				},
+				"verification_gas_limit": {
+					Type:        framework.TypeString,
+					Description: "The gas limit of the verification.",
+				},
+				"pre_verification_gas": {
+					Type:        framework.TypeString,
+					Description: "The gas paid to the bundler for pre-verification execution and calldata.",
+				},
+				"max_fee_per_gas": {
+					Type:        framework.TypeString,
+					Description: "The maximum fee per gas in wei.",
+				},
+				"max_priority_fee_per_gas": {
+					Type:        framework.TypeString,
+					Description: "The maximum priority fee per gas in wei.",
+				},
+				"paymaster_and_data": {
+					Type:        framework.TypeString,
+					Description: "The paymaster address followed by its data, empty if self-sponsored.",
+				},
+				"signature_type": {
+					Type:        framework.TypeString,
+					Description: "eth_sign to sign the userOpHash with the personal message prefix, raw to sign it directly - defaults to eth_sign.",
+					Default:     userOpSignatureEthSign,
+				},
+				"override_fee_limits": {
+					Type:        framework.TypeBool,
+					Description: "Sign even if the fee violates the fee limits of the chain, when the mount config allows fee limit overrides.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signUserOperation,
+					Summary:  "sign an ERC-4337 user operation",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) signUserOperation(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	addresses := map[string]common.Address{}
+	for _, key := range []string{"entry_point", "sender"} {
+		value := dataWrapper.GetString(key, "")
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid %s %s", key, value)
+		}
+		addresses[key] = common.HexToAddress(value)
+	}
+
+	numbers := map[string]*big.Int{}
+	for _, key := range []string{"call_gas_limit", "verification_gas_limit", "pre_verification_gas", "max_fee_per_gas", "max_priority_fee_per_gas"} {
+		value, err := dataWrapper.MustGetBigInt(key)
+		if err != nil || value == nil {
+			return nil, fmt.Errorf("invalid %s", key)
+		}
+		numbers[key] = value
+	}
+	for _, key := range []string{"chainID", "nonce"} {
+		value, err := requiredNumber(dataWrapper, key)
+		if err != nil {
+			return nil, err
+		}
+		numbers[key] = value
+	}
+	if numbers["chainID"].Sign() <= 0 {
+		return nil, fmt.Errorf("invalid chainID %s", numbers["chainID"])
+	}
+
+	bytesFields := map[string][]byte{}
+	for _, key := range []string{"init_code", "call_data", "paymaster_and_data"} {
+		value := dataWrapper.GetString(key, "")
+		if value == "" {
+			continue
+		}
+		decoded, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, utils.ErrorHandler(key, err)
+		}
+		bytesFields[key] = decoded
+	}
+
+	signatureType := dataWrapper.GetString("signature_type", userOpSignatureEthSign)
+	if signatureType != userOpSignatureEthSign && signatureType != userOpSignatureRaw {
+		return nil, fmt.Errorf("unsupported signature_type %s", signatureType)
+	}
+
+	userOp := &model.UserOperation{
+		Version:              dataWrapper.GetString("entry_point_version", model.EntryPointV07),
+		Sender:               addresses["sender"],
+		Nonce:                numbers["nonce"],
+		InitCode:             bytesFields["init_code"],
+		CallData:             bytesFields["call_data"],
+		CallGasLimit:         numbers["call_gas_limit"],
+		VerificationGasLimit: numbers["verification_gas_limit"],
+		PreVerificationGas:   numbers["pre_verification_gas"],
+		MaxFeePerGas:         numbers["max_fee_per_gas"],
+		MaxPriorityFeePerGas: numbers["max_priority_fee_per_gas"],
+		PaymasterAndData:     bytesFields["paymaster_and_data"],
+	}
+	entryPoint := addresses["entry_point"]
+	chainID := numbers["chainID"]
+
+	userOpHash, err := userOp.Hash(entryPoint, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := readEthereumAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.AllowsEntryPoint(entryPoint.Hex()) {
+		return nil, fmt.Errorf("entry point %s is not allowed for this account", entryPoint.Hex())
+	}
+	if !account.AllowsSender(userOp.Sender.Hex()) {
+		return nil, fmt.Errorf("sender %s is not allowed for this account", userOp.Sender.Hex())
+	}
+
+	if !userOp.TotalGas().IsUint64() {
+		return nil, fmt.Errorf("gas limits are too large")
+	}
+	feeWarning, err := b.checkFeeLimits(ctx, req, chainID, userOp.TotalGas().Uint64(), userOp.MaxFeePerGas, userOp.MaxPriorityFeePerGas, nil, dataWrapper.GetBool("override_fee_limits", false))
+	if err != nil {
+		return nil, err
+	}
+
+	// the sender executes the call, recorded by the beneficiary and amount it transfers;
+	// call data other than execute leaves them empty so that roles bounding them refuse it
+	historyEntry := &model.HistoryEntry{
+		Type:     model.SignatureTypeUserOperation,
+		ChainID:  chainID.String(),
+		Contract: userOp.Sender.Hex(),
+		Digest:   userOpHash.Hex(),
+	}
+	if dest, value, calldata, ok := userOp.Execution(); ok {
+		beneficiary, amount, _ := model.CallTransfer(dest, value, calldata)
+		historyEntry.To, historyEntry.Value = beneficiary.Hex(), amount.String()
+	}
+	err = b.checkRole(ctx, req, data, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.HexToECDSA(account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing private key")
+	}
+	defer utils.ZeroKey(privateKey)
+
+	var signature []byte
+	if signatureType == userOpSignatureEthSign {
+		signature, _, err = utils.SignPersonalMessage(userOpHash.Bytes(), privateKey)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		signature, err = crypto.Sign(userOpHash.Bytes(), privateKey)
+		if err != nil {
+			return nil, err
+		}
+		signature[crypto.RecoveryIDOffset] += 27
+	}
+
+	err = b.recordSignature(ctx, req, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	maxFee := model.MaxTransactionFee(userOp.TotalGas().Uint64(), userOp.MaxFeePerGas)
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"owner":        account.Address,
+			"sender":       userOp.Sender.Hex(),
+			"entry_point":  entryPoint.Hex(),
+			"user_op_hash": userOpHash.Hex(),
+			"signature":    hexutil.Encode(signature),
+			"max_fee":      maxFee.String(),
+			"max_fee_eth":  utils.FormatDecimalAmount(maxFee, 18),
+		},
+	}
+	if feeWarning != "" {
+		resp.AddWarning(feeWarning)
+	}
+
+	return resp, nil
+}
@@ -2,7 +2,10 @@ package path
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
 	"vault-hd-wallet/model"
 	"vault-hd-wallet/utils"
 
@@ -13,22 +16,55 @@ import (
 
 // WalletPaths aa
 func WalletPaths(b *PluginBackend) []*framework.Path {
+	walletFields := map[string]*framework.FieldSchema{
+		"mnemonic": {
+			Type:         framework.TypeString,
+			Default:      "",
+			DisplayAttrs: sensitive,
+		},
+		"passphrase": {
+			Type:         framework.TypeString,
+			Default:      "",
+			DisplayAttrs: sensitive,
+		},
+		"seed": {
+			Type:         framework.TypeString,
+			Default:      "",
+			Description:  "A hex encoded BIP-32 seed to import instead of a mnemonic.",
+			DisplayAttrs: sensitive,
+		},
+		"xprv": {
+			Type:         framework.TypeString,
+			Default:      "",
+			Description:  "A BIP-32 master extended private key, xprv or tprv, to import instead of a mnemonic. The wallet has no seed.",
+			DisplayAttrs: sensitive,
+		},
+		"entropy_bits": {
+			Type:        framework.TypeInt,
+			Description: "The entropy of a generated mnemonic, 128 to 256 bits by steps of 32 - defaults to the entropy_bits of the mount config.",
+		},
+		"language": {
+			Type:        framework.TypeString,
+			Default:     model.DefaultMnemonicLanguage,
+			Description: "The BIP-39 wordlist of a generated mnemonic. The language of imported mnemonics is detected.",
+		},
+		"exportable": {
+			Type:        framework.TypeBool,
+			Default:     true,
+			Description: "Whether the mnemonic is returned and the wallet can be exported. Non-exportable wallets are generated and never reveal their mnemonic.",
+		},
+	}
+	for field, schema := range slip39Fields() {
+		walletFields[field] = schema
+	}
+
 	return []*framework.Path{
 		{
 			Pattern:         "wallet/?",
 			HelpSynopsis:    "New wallet by generating or importing mnemonic",
 			HelpDescription: `New wallet by generating or importing mnemonic`,
 			ExistenceCheck:  utils.PathExistenceCheck,
-			Fields: map[string]*framework.FieldSchema{
-				"mnemonic": {
-					Type:    framework.TypeString,
-					Default: "",
-				},
-				"passphrase": {
-					Type:    framework.TypeString,
-					Default: "",
-				},
-			},
+			Fields:          walletFields,
 			Operations: map[logical.Operation]framework.OperationHandler{
 				logical.CreateOperation: &framework.PathOperation{
 					Callback: b.createWallet,
@@ -41,9 +77,32 @@ func WalletPaths(b *PluginBackend) []*framework.Path {
 				},
 			},
 		},
+		{
+			Pattern:         "wallet/metadata",
+			HelpSynopsis:    "read the wallet metadata",
+			HelpDescription: `read the metadata of the wallet, such as whether it is exportable, without revealing any secret`,
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readWalletMetadata,
+					Summary:  "read the wallet metadata",
+				},
+			},
+		},
 	}
 }
 
+// sensitive marks the fields carrying secrets, which are masked by the UI
+var sensitive = &framework.DisplayAttributes{Sensitive: true}
+
+// requireResponseWrapping refuses responses carrying secrets unless they are
+// delivered once through a single-use wrapping token
+func requireResponseWrapping(req *logical.Request) error {
+	if req.WrapInfo == nil || req.WrapInfo.TTL == 0 {
+		return errors.New("the response contains secrets and must be requested with response wrapping, e.g. the X-Vault-Wrap-TTL header")
+	}
+	return nil
+}
+
 func (b *PluginBackend) createWallet(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 	mnemonic, ok := data.Get("mnemonic").(string)
 	if !ok {
@@ -55,33 +114,135 @@ func (b *PluginBackend) createWallet(ctx context.Context, req *logical.Request,
 		return nil, errors.New("passphrase is not a string")
 	}
 
-	if mnemonic == "" {
-		entropy, err := bip39.NewEntropy(256)
+	seed := data.Get("seed").(string)
+	xprv := data.Get("xprv").(string)
+
+	imports := 0
+	for _, input := range []string{mnemonic, seed, xprv} {
+		if input != "" {
+			imports++
+		}
+	}
+	if imports > 1 {
+		return nil, errors.New("only one of mnemonic, seed and xprv can be imported")
+	}
+	if passphrase != "" && mnemonic == "" && imports > 0 {
+		return nil, errors.New("passphrase only applies to mnemonics")
+	}
+
+	exportable := data.Get("exportable").(bool)
+	if !exportable && imports > 0 {
+		return nil, errors.New("non-exportable wallets are generated by the plugin and cannot be imported")
+	}
+
+	config, err := model.ReadConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	// wallets backed up with SLIP-39 shares return the shares instead of the mnemonic
+	_, sharing := data.GetOk("groups")
+	if sharing {
+		if !exportable {
+			return nil, errors.New("non-exportable wallets cannot be backed up with SLIP-39 shares")
+		}
+		if !config.ReturnMnemonic {
+			return nil, errors.New("exporting the wallet is disabled by the mount config")
+		}
+	}
+
+	generated := imports == 0
+	if generated && exportable && config.ReturnMnemonic && !sharing {
+		err = requireResponseWrapping(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if generated {
+		entropyBits := config.EntropyBits
+		if value, ok := data.GetOk("entropy_bits"); ok {
+			entropyBits = value.(int)
+		}
+
+		entropy, err := bip39.NewEntropy(entropyBits)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entropy_bits %d, must be a multiple of 32 between 128 and 256", entropyBits)
+		}
+
+		mnemonic, err = model.NewMnemonic(entropy, data.Get("language").(string))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var wallet *model.Wallet
+	switch {
+	case seed != "":
+		seedBytes, err := hex.DecodeString(strings.TrimPrefix(seed, "0x"))
+		if err != nil {
+			return nil, errors.New("seed must be hex encoded")
+		}
+
+		wallet, err = model.NewWalletFromSeed(seedBytes)
+		if err != nil {
+			return nil, err
+		}
+		wallet.Source = model.WalletSourceSeed
+	case xprv != "":
+		wallet, err = model.NewWalletFromExtendedKey(xprv)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		wallet, err = model.NewWalletFromMnemonic(mnemonic, passphrase)
 		if err != nil {
 			return nil, err
 		}
+		wallet.Source = model.WalletSourceMnemonic
+		if generated {
+			wallet.Source = model.WalletSourceGenerated
+		}
+	}
+	wallet.NonExportable = !exportable
 
-		mnemonic, err = bip39.NewMnemonic(entropy)
+	var shares [][]string
+	if sharing {
+		shares, err = splitWallet(wallet, data)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	wallet, err := model.NewWalletFromMnemonic(mnemonic, passphrase)
+	err = putWallet(ctx, req, wallet)
 	if err != nil {
 		return nil, err
 	}
 
-	entry, err := logical.StorageEntryJSON(req.Path, wallet)
+	// the undelivered shares of the replaced wallet must not be delivered
+	err = clearSLIP39Shares(ctx, req, model.CeremonySharesPrefix)
 	if err != nil {
 		return nil, err
 	}
 
-	err = req.Storage.Put(ctx, entry)
+	if sharing {
+		return b.storeSLIP39Shares(ctx, req, model.SLIP39SharesPrefix, shares)
+	}
+
+	err = clearSLIP39Shares(ctx, req, model.SLIP39SharesPrefix)
 	if err != nil {
 		return nil, err
 	}
 
+	if !exportable {
+		return walletMetadataResponse(wallet), nil
+	}
+
+	// imported mnemonics are known to the requester and never echoed back
+	if !generated || !config.ReturnMnemonic {
+		return nil, nil
+	}
+
 	return &logical.Response{
 		Data: map[string]interface{}{
 			"mnemonic": mnemonic,
@@ -90,13 +251,36 @@ func (b *PluginBackend) createWallet(ctx context.Context, req *logical.Request,
 
 }
 
+func putWallet(ctx context.Context, req *logical.Request, wallet *model.Wallet) error {
+	entry, err := logical.StorageEntryJSON(model.WalletPath, wallet)
+	if err != nil {
+		return err
+	}
+
+	return req.Storage.Put(ctx, entry)
+}
+
 func (b *PluginBackend) readWallet(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	config, err := model.ReadConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
 
 	wallet, err := model.ReadWallet(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
+	err = checkExportable(config, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	err = requireResponseWrapping(req)
+	if err != nil {
+		return nil, err
+	}
+
 	return &logical.Response{
 		Data: map[string]interface{}{
 			"wallet": wallet,
@@ -104,3 +288,22 @@ func (b *PluginBackend) readWallet(ctx context.Context, req *logical.Request, da
 	}, nil
 
 }
+
+func (b *PluginBackend) readWalletMetadata(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	wallet, err := model.ReadWallet(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletMetadataResponse(wallet), nil
+}
+
+func walletMetadataResponse(wallet *model.Wallet) *logical.Response {
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"exportable": wallet.Exportable(),
+			"source":     wallet.Source,
+			"has_seed":   wallet.HasSeed(),
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package path
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// XpubPaths returns the paths exporting the watch-only extended public keys of accounts
+func XpubPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "xpub",
+			HelpSynopsis:    "export the extended public key of an account",
+			HelpDescription: `export the extended public key of a hardened account-level path with its key origin, so that watch-only systems derive the addresses of the account without any signing power`,
+			Fields: map[string]*framework.FieldSchema{
+				"path": {
+					Type:        framework.TypeString,
+					Description: "The hardened account-level derivation path, e.g. m/84'/0'/0'.",
+				},
+				"format": {
+					Type:        framework.TypeString,
+					Description: "The SLIP-132 format, xpub, ypub or zpub - defaults to ypub for BIP-49 paths, zpub for BIP-84 paths and xpub otherwise.",
+				},
+				"network": {
+					Type:        framework.TypeString,
+					Description: "The bitcoin network, testnet and regtest keys being encoded as tpub, upub or vpub - defaults to mainnet.",
+					Default:     model.NetworkMainnet,
+				},
+				"chain": {
+					Type:        framework.TypeString,
+					Description: "The name of a bitcoin chain profile providing the network and, through its address format, the format.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.readXpub,
+					Summary:  "export the extended public key of an account",
+				},
+			},
+		},
+	}
+}
+
+// the extended public key formats of the bitcoin address types
+var addressTypeXpubFormats = map[string]string{
+	model.AddressTypeP2PKH:      model.XpubFormat,
+	model.AddressTypeP2SHP2WPKH: model.YpubFormat,
+	model.AddressTypeP2WPKH:     model.ZpubFormat,
+	model.AddressTypeP2TR:       model.XpubFormat,
+}
+
+func (b *PluginBackend) readXpub(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	pathField := dataWrapper.GetString("path", "")
+	if !strings.HasPrefix(pathField, "m/") {
+		return nil, errors.New("path must be an absolute derivation path such as m/84'/0'/0'")
+	}
+	path, err := model.ParseDerivationPath(pathField)
+	if err != nil {
+		return nil, err
+	}
+
+	format := dataWrapper.GetString("format", "")
+	if format == "" {
+		format = model.DefaultXpubFormat(path)
+	}
+	network := dataWrapper.GetString("network", model.NetworkMainnet)
+
+	// a bitcoin chain profile provides the parameters not given explicitly
+	if chain := dataWrapper.GetString("chain", ""); chain != "" {
+		profile, err := model.ReadChainProfile(ctx, req.Storage, chain)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil {
+			return nil, fmt.Errorf("chain profile %s is not existed", chain)
+		}
+		if profile.Family != model.FamilyBitcoin {
+			return nil, fmt.Errorf("chain profile %s is not a bitcoin profile", chain)
+		}
+		if _, ok := data.GetOk("format"); !ok && profile.AddressFormat != "" {
+			format = addressTypeXpubFormats[profile.AddressFormat]
+		}
+		if _, ok := data.GetOk("network"); !ok && profile.ChainID != "" {
+			network = profile.ChainID
+		}
+	}
+
+	wallet, err := model.ReadWallet(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := wallet.DeriveExtendedPublicKey(path, format, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"xpub":               key.Key,
+			"format":             key.Format,
+			"master_fingerprint": key.MasterFingerprint,
+			"path":               key.Path.String(),
+			"descriptor_key":     key.DescriptorKey(),
+		},
+	}, nil
+}
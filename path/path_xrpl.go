@@ -0,0 +1,111 @@
+package path
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"vault-hd-wallet/model"
+	"vault-hd-wallet/utils"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// XRPLPaths returns the paths of XRP Ledger transaction signing
+func XRPLPaths(b *PluginBackend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern:         "accounts/" + framework.GenericNameRegex("name") + "/sign-xrpl-tx",
+			HelpSynopsis:    "sign an XRP Ledger transaction",
+			HelpDescription: `sign a binary serialized XRP Ledger transaction and return the signed blob`,
+			ExistenceCheck:  utils.PathExistenceCheck,
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type: framework.TypeString,
+				},
+				"tx_blob": {
+					Type:        framework.TypeString,
+					Description: "The hex encoded binary transaction, with the SigningPubKey of the account.",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{
+					Callback: b.signXRPLTransaction,
+					Summary:  "sign an XRP Ledger transaction",
+				},
+			},
+		},
+	}
+}
+
+func (b *PluginBackend) signXRPLTransaction(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	dataWrapper := utils.NewFieldDataWrapper(data)
+
+	txBlob, err := dataWrapper.MustGetString("tx_blob")
+	if err != nil {
+		return nil, utils.ErrorHandler("tx_blob", err)
+	}
+	blob, err := hex.DecodeString(txBlob)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tx_blob")
+	}
+
+	tx, err := model.ParseXRPLTransaction(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := readChainAccount(ctx, req, model.ChainXRPL)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Account() != account.Address {
+		return nil, fmt.Errorf("the transaction is sent by %s, not by the account %s", tx.Account(), account.Address)
+	}
+
+	historyEntry := &model.HistoryEntry{
+		Type:   model.SignatureTypeXRPL,
+		To:     tx.Destination(),
+		Value:  tx.Amount(),
+		Digest: hex.EncodeToString(tx.SigningHash()),
+	}
+	err = b.checkRole(ctx, req, data, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKeyBytes, err := hex.DecodeString(account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing private key")
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), privateKeyBytes)
+	defer utils.ZeroKey(privateKey.ToECDSA())
+
+	signature, signedBlob, err := tx.Sign(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	hash := strings.ToUpper(hex.EncodeToString(model.XRPLTransactionHash(signedBlob)))
+
+	historyEntry.TxHash = hash
+	err = b.recordSignature(ctx, req, data.Get("name").(string), historyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"address":          account.Address,
+			"transaction_type": tx.TransactionType(),
+			"destination":      tx.Destination(),
+			"amount":           tx.Amount(),
+			"fee":              tx.Fee(),
+			"sequence":         tx.Sequence(),
+			"signature":        strings.ToUpper(hex.EncodeToString(signature)),
+			"hash":             hash,
+			"tx_blob":          strings.ToUpper(hex.EncodeToString(signedBlob)),
+		},
+	}, nil
+}
@@ -8,4 +8,64 @@ path "hdwallet/accounts/{{identity.entity.name}}/sign-tx"{
 
 path "hdwallet/accounts/{{identity.entity.name}}/sign"{
     capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-siwe"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-erc20-transfer"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-erc20-approve"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-safe-tx"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-user-operation"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-psbt"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-message"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/verify-message"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-solana-tx"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-cosmos"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-tron-tx"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/accounts/{{identity.entity.name}}/sign-xrpl-tx"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/self/address"{
+    capabilities = ["read"]
+}
+
+path "hdwallet/self/sign"{
+    capabilities = ["create"]
+}
+
+path "hdwallet/self/sign-tx"{
+    capabilities = ["create"]
 }
\ No newline at end of file
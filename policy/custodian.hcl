@@ -0,0 +1,11 @@
+path "hdwallet/ceremony" {
+    capabilities = ["read"]
+}
+
+path "hdwallet/ceremony/contribute" {
+    capabilities = ["create"]
+}
+
+path "hdwallet/ceremony/share" {
+    capabilities = ["read"]
+}
\ No newline at end of file
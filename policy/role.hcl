@@ -0,0 +1,3 @@
+path "hdwallet/roles/erc20-polygon/accounts/+/sign-erc20-transfer"{
+    capabilities = ["create"]
+}
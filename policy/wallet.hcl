@@ -2,6 +2,38 @@ path "hdwallet/wallet" {
   capabilities = ["create", "read"]
 }
 
+path "hdwallet/wallet/metadata" {
+  capabilities = ["read"]
+}
+
 path "hdwallet/accounts/*"{
-    capabilities = ["create", "read"]
+    capabilities = ["create", "read", "update", "list"]
+}
+
+path "hdwallet/contracts/*"{
+    capabilities = ["create", "read", "update", "delete", "list"]
+}
+
+path "hdwallet/fee-limits/*"{
+    capabilities = ["create", "read", "update", "delete", "list"]
+}
+
+path "hdwallet/chains/*"{
+    capabilities = ["create", "read", "update", "delete", "list"]
+}
+
+path "hdwallet/config"{
+    capabilities = ["create", "read", "update", "delete"]
+}
+
+path "hdwallet/roles/*"{
+    capabilities = ["create", "read", "update", "delete", "list"]
+}
+
+path "hdwallet/wallet/slip39/*"{
+    capabilities = ["create", "read", "update", "delete", "list"]
+}
+
+path "hdwallet/ceremony"{
+    capabilities = ["create", "read", "delete"]
 }
\ No newline at end of file
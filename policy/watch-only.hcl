@@ -0,0 +1,3 @@
+path "hdwallet/xpub" {
+    capabilities = ["read"]
+}
\ No newline at end of file
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/bech32"
+)
+
+// bech32mConst is the checksum constant of BIP-350, bech32 uses 1
+const bech32mConst = 0x2bc830a3
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var bech32Generator = []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// EncodeSegWitAddress encodes a witness program of any version as a BIP-173
+// or BIP-350 address: bech32 for version 0 and bech32m for version 1 and above.
+func EncodeSegWitAddress(hrp string, witnessVersion byte, witnessProgram []byte) (string, error) {
+	if witnessVersion > 16 {
+		return "", fmt.Errorf("invalid witness version %d", witnessVersion)
+	}
+
+	converted, err := bech32.ConvertBits(witnessProgram, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	data := append([]byte{witnessVersion}, converted...)
+
+	if witnessVersion == 0 {
+		return bech32.Encode(hrp, data)
+	}
+
+	return encodeBech32m(hrp, data), nil
+}
+
+// DecodeSegWitAddress decodes a BIP-173 or BIP-350 address into its witness
+// version and program, checking the checksum variant matches the version.
+func DecodeSegWitAddress(address string) (string, byte, []byte, error) {
+	hrp, data, err := bech32.Decode(address)
+	if err == nil {
+		if len(data) == 0 || data[0] != 0 {
+			return "", 0, nil, errors.New("witness version 1+ addresses must use bech32m")
+		}
+	} else {
+		hrp, data, err = decodeBech32m(address)
+		if err != nil {
+			return "", 0, nil, err
+		}
+		if len(data) == 0 || data[0] == 0 {
+			return "", 0, nil, errors.New("witness version 0 addresses must use bech32")
+		}
+	}
+
+	if data[0] > 16 {
+		return "", 0, nil, fmt.Errorf("invalid witness version %d", data[0])
+	}
+
+	program, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return "", 0, nil, fmt.Errorf("invalid witness program length %d", len(program))
+	}
+	if data[0] == 0 && len(program) != 20 && len(program) != 32 {
+		return "", 0, nil, fmt.Errorf("invalid witness version 0 program length %d", len(program))
+	}
+
+	return hrp, data[0], program, nil
+}
+
+func encodeBech32m(hrp string, data []byte) string {
+	values := append(bech32HRPExpand(hrp), toInts(data)...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ bech32mConst
+
+	var builder strings.Builder
+	builder.WriteString(hrp)
+	builder.WriteString("1")
+	for _, b := range data {
+		builder.WriteByte(bech32Charset[b])
+	}
+	for i := 0; i < 6; i++ {
+		builder.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+
+	return builder.String()
+}
+
+func decodeBech32m(address string) (string, []byte, error) {
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return "", nil, errors.New("mixed case address")
+	}
+	address = strings.ToLower(address)
+
+	separator := strings.LastIndexByte(address, '1')
+	if separator < 1 || separator+7 > len(address) || len(address) > 90 {
+		return "", nil, errors.New("invalid bech32m address")
+	}
+
+	hrp := address[:separator]
+	data := make([]byte, 0, len(address)-separator-1)
+	for _, c := range address[separator+1:] {
+		index := strings.IndexRune(bech32Charset, c)
+		if index < 0 {
+			return "", nil, fmt.Errorf("invalid bech32m character %q", c)
+		}
+		data = append(data, byte(index))
+	}
+
+	if bech32Polymod(append(bech32HRPExpand(hrp), toInts(data)...)) != bech32mConst {
+		return "", nil, errors.New("invalid bech32m checksum")
+	}
+
+	return hrp, data[:len(data)-6], nil
+}
+
+func bech32Polymod(values []int) int {
+	chk := 1
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ v
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= bech32Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []int {
+	values := make([]int, 0, len(hrp)*2+1)
+	for _, c := range hrp {
+		values = append(values, int(c>>5))
+	}
+	values = append(values, 0)
+	for _, c := range hrp {
+		values = append(values, int(c&31))
+	}
+	return values
+}
+
+func toInts(data []byte) []int {
+	values := make([]int, len(data))
+	for i, b := range data {
+		values[i] = int(b)
+	}
+	return values
+}
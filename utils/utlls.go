@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"math/big"
 	"regexp"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/logical"
 )
@@ -29,6 +32,20 @@ func ZeroKey(k *ecdsa.PrivateKey) {
 	}
 }
 
+// SignPersonalMessage signs the message with the EIP-191 personal_sign prefix.
+// The recovery id of the returned signature is 27 or 28 as wallets expect.
+func SignPersonalMessage(message []byte, k *ecdsa.PrivateKey) ([]byte, []byte, error) {
+	hash := accounts.TextHash(message)
+
+	signature, err := crypto.Sign(hash, k)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return signature, hash, nil
+}
+
 // ValidNumber returns a valid positive integer
 func ValidNumber(input string) *big.Int {
 	if input == "" {
@@ -42,6 +59,52 @@ func ValidNumber(input string) *big.Int {
 	return amount.Abs(amount)
 }
 
+// ParseDecimalAmount converts a decimal amount in human units, such as "1.5",
+// into an integer amount of the smallest unit with the given decimals.
+func ParseDecimalAmount(input string, decimals uint8) (*big.Int, error) {
+	matched, err := regexp.MatchString(`^[0-9]+(\.[0-9]+)?$`, input)
+	if !matched || err != nil {
+		return nil, fmt.Errorf("invalid decimal amount %s", input)
+	}
+
+	parts := strings.SplitN(input, ".", 2)
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = strings.TrimRight(parts[1], "0")
+	}
+	if len(fraction) > int(decimals) {
+		return nil, fmt.Errorf("amount %s has more than %d decimals", input, decimals)
+	}
+	fraction += strings.Repeat("0", int(decimals)-len(fraction))
+
+	amount, ok := new(big.Int).SetString(parts[0]+fraction, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal amount %s", input)
+	}
+
+	return amount, nil
+}
+
+// FormatDecimalAmount renders an integer amount of the smallest unit in human units
+func FormatDecimalAmount(amount *big.Int, decimals uint8) string {
+	digits := new(big.Int).Abs(amount).String()
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+
+	integer := digits[:len(digits)-int(decimals)]
+	fraction := strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+
+	result := integer
+	if fraction != "" {
+		result += "." + fraction
+	}
+	if amount.Sign() < 0 {
+		result = "-" + result
+	}
+	return result
+}
+
 func ErrorHandler(errorType string, err error) error {
 	return fmt.Errorf("error occurs at %s : %s", errorType, err.Error())
 }